@@ -6,10 +6,19 @@ import (
 	"fmt"
 	"journey/internal/api"
 	"journey/internal/api/spec"
-	"journey/internal/mailer/mailpit"
+	"journey/internal/auth"
+	"journey/internal/mailer"
+	"journey/internal/mailer/file"
+	"journey/internal/mailer/memory"
+	"journey/internal/mailer/outbox"
+	"journey/internal/mailer/smtp"
+	"journey/internal/mailer/templates"
+	"journey/internal/token"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -63,10 +72,69 @@ func run(ctx context.Context) error {
 		return err
 	}
 
-	si := api.NewAPI(pool, logger, mailpit.NewMailpit(pool))
+	tokens, err := token.NewSigner([]byte(os.Getenv("JOURNEY_TOKEN_SECRET")))
+	if err != nil {
+		return fmt.Errorf("invalid JOURNEY_TOKEN_SECRET: %w", err)
+	}
+
+	var jwt *token.JWTVerifier
+	if secret := os.Getenv("JOURNEY_JWT_SECRET"); secret != "" {
+		verifier, err := token.NewJWTVerifier([]byte(secret))
+		if err != nil {
+			return fmt.Errorf("invalid JOURNEY_JWT_SECRET: %w", err)
+		}
+		jwt = &verifier
+	}
+
+	renderer, err := templates.NewRenderer()
+	if err != nil {
+		return err
+	}
+
+	sender, closeSender, err := newSender(logger)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = closeSender() }()
+
+	publicBaseURL := getenv("JOURNEY_PUBLIC_BASE_URL", "http://localhost:8080")
+
+	// JOURNEY_MAILER_FROM used to be JOURNEY_SMTP_FROM, which is still read
+	// so that existing deployments keep their sender.
+	from := getenv("JOURNEY_MAILER_FROM", getenv("JOURNEY_SMTP_FROM", "mailpit@journey.com"))
+
+	appMailer := mailer.NewMailer(
+		pool,
+		renderer,
+		sender,
+		tokens,
+		from,
+		publicBaseURL,
+	)
+
+	dispatcher := outbox.NewDispatcher(pool, logger, appMailer)
+	dispatcherCtx, stopDispatcher := context.WithCancel(ctx)
+	dispatcherDone := make(chan struct{})
+	go func() {
+		defer close(dispatcherDone)
+		dispatcher.Run(dispatcherCtx)
+	}()
+	defer func() {
+		stopDispatcher()
+		<-dispatcherDone
+	}()
+
+	si := api.NewAPI(
+		pool,
+		logger,
+		tokens,
+		strings.Split(os.Getenv("JOURNEY_ADMIN_EMAILS"), ","),
+		publicBaseURL,
+	)
 	r := chi.NewMux()
 	r.Use(
 		middleware.RequestID,
+		auth.Middleware(tokens, jwt),
 		middleware.Recoverer,
 		httputils.ChiLogger(logger),
 	)
@@ -114,3 +182,65 @@ func run(ctx context.Context) error {
 
 	return nil
 }
+
+// newSender returns the mail backend picked by JOURNEY_MAILER, along with a
+// function that releases it.
+func newSender(logger *zap.Logger) (mailer.Sender, func() error, error) {
+	switch backend := getenv("JOURNEY_MAILER", "smtp"); backend {
+	case "smtp":
+		cfg, err := smtpConfig()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sender, err := smtp.NewSMTP(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sender, sender.Close, nil
+	case "file":
+		dir := getenv("JOURNEY_MAILER_DIR", "mail")
+
+		sender, err := file.NewFile(dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		logger.Info("writing emails to disk", zap.String("dir", dir))
+		return sender, func() error { return nil }, nil
+	case "memory":
+		logger.Info("keeping emails in memory, they will not be delivered")
+		return memory.NewMemory(), func() error { return nil }, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid JOURNEY_MAILER %q", backend)
+	}
+}
+
+// smtpConfig reads the SMTP relay from the JOURNEY_SMTP_* environment
+// variables, defaulting to the mailpit container from docker-compose.
+func smtpConfig() (smtp.Config, error) {
+	cfg := smtp.Config{
+		Host:     getenv("JOURNEY_SMTP_HOST", "mailpit"),
+		Port:     1025,
+		TLS:      getenv("JOURNEY_SMTP_TLS", smtp.TLSNone),
+		Auth:     os.Getenv("JOURNEY_SMTP_AUTH"),
+		Username: os.Getenv("JOURNEY_SMTP_USERNAME"),
+		Password: os.Getenv("JOURNEY_SMTP_PASSWORD"),
+	}
+
+	if port := os.Getenv("JOURNEY_SMTP_PORT"); port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return smtp.Config{}, fmt.Errorf("invalid JOURNEY_SMTP_PORT %q: %w", port, err)
+		}
+		cfg.Port = p
+	}
+
+	return cfg, nil
+}
+
+func getenv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"journey/internal/api/spec"
+	"journey/internal/pgstore"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// activityCategories are the values accepted by the activities category
+// filter.
+var activityCategories = map[string]bool{
+	pgstore.ActivityCategoryFood:        true,
+	pgstore.ActivityCategoryTransport:   true,
+	pgstore.ActivityCategoryLodging:     true,
+	pgstore.ActivityCategorySightseeing: true,
+	pgstore.ActivityCategoryOther:       true,
+}
+
+// Scopes of an activity update or delete.
+const (
+	activityScopeOccurrence = "occurrence"
+	activityScopeSeries     = "series"
+)
+
+// seriesScope reports whether scope asks to change the whole series of an
+// activity rather than the one occurrence. ok is false when scope is unknown.
+func seriesScope(scope *string) (series bool, ok bool) {
+	if scope == nil {
+		return false, true
+	}
+
+	switch *scope {
+	case activityScopeOccurrence:
+		return false, true
+	case activityScopeSeries:
+		return true, true
+	}
+	return false, false
+}
+
+// activityEnd is when an activity described by body ends, either given as is
+// or computed from its duration. It's nil when body has neither.
+func activityEnd(body spec.CreateActivityRequest) *time.Time {
+	if body.EndsAt != nil {
+		return body.EndsAt
+	}
+	if body.DurationMinutes != nil {
+		endsAt := body.OccursAt.Add(time.Duration(*body.DurationMinutes) * time.Minute)
+		return &endsAt
+	}
+	return nil
+}
+
+// createRequest is the update request body as a create one, so that both go
+// through the same validation and mapping. An update never repeats.
+func createRequest(body spec.UpdateActivityRequest) spec.CreateActivityRequest {
+	return spec.CreateActivityRequest{
+		Category:        body.Category,
+		Cost:            body.Cost,
+		DurationMinutes: body.DurationMinutes,
+		EndsAt:          body.EndsAt,
+		Location:        body.Location,
+		Notes:           body.Notes,
+		OccursAt:        body.OccursAt,
+		Timezone:        body.Timezone,
+		Title:           body.Title,
+	}
+}
+
+// newActivityParams maps an already validated request body to the columns of
+// an activity of the trip tripID.
+func newActivityParams(tripID uuid.UUID, body spec.CreateActivityRequest) pgstore.CreateActivityParams {
+	params := pgstore.CreateActivityParams{
+		TripID:   tripID,
+		Title:    body.Title,
+		OccursAt: pgtype.Timestamptz{Valid: true, Time: body.OccursAt},
+		Category: pgstore.ActivityCategoryOther,
+	}
+	if body.Timezone != nil {
+		params.Timezone = pgtype.Text{Valid: true, String: *body.Timezone}
+	}
+	if endsAt := activityEnd(body); endsAt != nil {
+		params.EndsAt = pgtype.Timestamptz{Valid: true, Time: *endsAt}
+	}
+	if body.Location != nil {
+		params.LocationName = pgtype.Text{Valid: true, String: body.Location.Name}
+		if body.Location.Latitude != nil && body.Location.Longitude != nil {
+			params.Latitude = pgtype.Float8{Valid: true, Float64: *body.Location.Latitude}
+			params.Longitude = pgtype.Float8{Valid: true, Float64: *body.Location.Longitude}
+		}
+	}
+	if body.Notes != nil {
+		params.Notes = pgtype.Text{Valid: true, String: *body.Notes}
+	}
+	if body.Category != nil {
+		params.Category = *body.Category
+	}
+	if body.Cost != nil {
+		// The column keeps two decimal places, so round here rather than let
+		// a float like 0.1 reach the database as 0.1000000000000000055.
+		_ = params.CostAmount.Scan(strconv.FormatFloat(body.Cost.Amount, 'f', 2, 64))
+		params.CostCurrency = pgtype.Text{Valid: true, String: body.Cost.Currency}
+	}
+	return params
+}
+
+// activityResponses maps activities with activityResponse, never returning
+// nil so that empty lists are sent as [].
+func activityResponses(trip pgstore.Trip, activities []pgstore.Activity) []spec.GetTripActivitiesResponseInnerArray {
+	response := make([]spec.GetTripActivitiesResponseInnerArray, len(activities))
+	for i, activity := range activities {
+		response[i] = activityResponse(trip, activity)
+	}
+	return response
+}
+
+// newActivitySeries maps an already validated request body with a recurrence
+// to the series and to the columns of each of its occurrences.
+func newActivitySeries(
+	trip pgstore.Trip,
+	body spec.CreateActivityRequest,
+) (pgstore.InsertActivitySeriesParams, []pgstore.CreateActivityParams) {
+	rule := body.Recurrence
+	series := pgstore.InsertActivitySeriesParams{
+		TripID:    trip.ID,
+		Frequency: rule.Frequency,
+		Interval:  1,
+		Weekdays:  []string{},
+	}
+	if rule.Interval != nil {
+		series.Interval = int32(*rule.Interval)
+	}
+	if rule.Frequency == pgstore.RecurrenceWeekly {
+		series.Weekdays = rule.Weekdays
+		if len(series.Weekdays) == 0 {
+			series.Weekdays = []string{weekdayNames[body.OccursAt.In(activityLocation(trip, body)).Weekday()]}
+		}
+	}
+	if rule.Until != nil {
+		series.Until = pgtype.Timestamptz{Valid: true, Time: *rule.Until}
+	}
+
+	first := newActivityParams(trip.ID, body)
+	duration := first.EndsAt.Time.Sub(first.OccursAt.Time)
+
+	var params []pgstore.CreateActivityParams
+	for _, at := range occurrences(trip, body) {
+		occurrence := first
+		occurrence.OccursAt = pgtype.Timestamptz{Valid: true, Time: at}
+		if first.EndsAt.Valid {
+			occurrence.EndsAt = pgtype.Timestamptz{Valid: true, Time: at.Add(duration)}
+		}
+		params = append(params, occurrence)
+	}
+
+	return series, params
+}
+
+// activitySeriesParams maps an already validated request body, given for the
+// occurrence activity, to the changes of every occurrence of its series: they
+// are moved as much as activity is.
+func activitySeriesParams(activity pgstore.Activity, body spec.CreateActivityRequest) pgstore.UpdateActivitySeriesParams {
+	fields := newActivityParams(activity.TripID, body)
+	params := pgstore.UpdateActivitySeriesParams{
+		Title:        fields.Title,
+		Shift:        pgtype.Interval{Valid: true, Microseconds: body.OccursAt.Sub(activity.OccursAt.Time).Microseconds()},
+		Timezone:     fields.Timezone,
+		LocationName: fields.LocationName,
+		Latitude:     fields.Latitude,
+		Longitude:    fields.Longitude,
+		Notes:        fields.Notes,
+		Category:     fields.Category,
+		CostAmount:   fields.CostAmount,
+		CostCurrency: fields.CostCurrency,
+		SeriesID:     activity.SeriesID.Bytes,
+	}
+	if fields.EndsAt.Valid {
+		params.Duration = pgtype.Interval{
+			Valid:        true,
+			Microseconds: fields.EndsAt.Time.Sub(fields.OccursAt.Time).Microseconds(),
+		}
+	}
+	return params
+}
+
+func activityResponse(trip pgstore.Trip, activity pgstore.Activity) spec.GetTripActivitiesResponseInnerArray {
+	loc := activity.Location(trip)
+	response := spec.GetTripActivitiesResponseInnerArray{
+		Category: activity.Category,
+		ID:       activity.ID.String(),
+		OccursAt: activity.OccursAt.Time.In(loc),
+		Timezone: loc.String(),
+		Title:    activity.Title,
+	}
+
+	if activity.EndsAt.Valid {
+		endsAt := activity.EndsAt.Time.In(loc)
+		response.EndsAt = &endsAt
+	}
+	if activity.LocationName.Valid {
+		response.Location = &spec.ActivityLocation{Name: activity.LocationName.String}
+		if activity.Latitude.Valid && activity.Longitude.Valid {
+			response.Location.Latitude = &activity.Latitude.Float64
+			response.Location.Longitude = &activity.Longitude.Float64
+		}
+	}
+	if activity.Notes.Valid {
+		response.Notes = &activity.Notes.String
+	}
+	if activity.SeriesID.Valid {
+		seriesID := uuid.UUID(activity.SeriesID.Bytes).String()
+		response.SeriesID = &seriesID
+	}
+	if activity.CostAmount.Valid {
+		amount, err := activity.CostAmount.Float64Value()
+		if err == nil {
+			response.Cost = &spec.ActivityCost{Amount: amount.Float64, Currency: activity.CostCurrency.String}
+		}
+	}
+
+	return response
+}
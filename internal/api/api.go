@@ -6,37 +6,173 @@ import (
 	"errors"
 	"fmt"
 	"journey/internal/api/spec"
+	"journey/internal/auth"
 	"journey/internal/pgstore"
+	"journey/internal/token"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/discord-gophers/goapi-gen/types"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
 type store interface {
-	CreateActivity(ctx context.Context, arg pgstore.CreateActivityParams) (uuid.UUID, error)
+	CreateCalendarFeed(ctx context.Context, arg pgstore.CreateCalendarFeedParams) (pgstore.CalendarFeed, error)
+	CreateTripInviteLink(ctx context.Context, arg pgstore.CreateTripInviteLinkParams) (pgstore.TripInviteLink, error)
 	CreateTripLink(ctx context.Context, arg pgstore.CreateTripLinkParams) (uuid.UUID, error)
 	CreateTrip(ctx context.Context, pool *pgxpool.Pool, params spec.CreateTripRequest) (uuid.UUID, error)
 
-	ConfirmParticipant(ctx context.Context, participantID uuid.UUID) error
-
+	DeleteActivity(ctx context.Context, activityID uuid.UUID) error
+	DeleteActivitySeries(ctx context.Context, seriesID uuid.UUID) error
+	DeleteCalendarFeed(ctx context.Context, arg pgstore.DeleteCalendarFeedParams) (int64, error)
+
+	EnqueueEmail(ctx context.Context, arg pgstore.EnqueueEmailParams) error
+
+	ConfirmParticipantWithToken(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		tripID uuid.UUID,
+		params pgstore.ConfirmParticipantParams,
+		token pgstore.UseTokenParams,
+	) (string, error)
+	DeclineParticipantWithToken(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		tripID uuid.UUID,
+		participantID uuid.UUID,
+		token pgstore.UseTokenParams,
+	) error
+	ConfirmTripAndInviteParticipants(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		tripID uuid.UUID,
+		token pgstore.UseTokenParams,
+	) error
+
+	GetActivity(ctx context.Context, activityID uuid.UUID) (pgstore.Activity, error)
+	GetCalendarFeedByToken(ctx context.Context, token string) (pgstore.CalendarFeed, error)
+	GetLastLoginEmailAt(ctx context.Context, recipient pgtype.Text) (pgtype.Timestamptz, error)
 	GetParticipant(ctx context.Context, particpantID uuid.UUID) (pgstore.Participant, error)
-	GetParticipants(ctx context.Context, tripID uuid.UUID) ([]pgstore.Participant, error)
+	GetParticipants(ctx context.Context, arg pgstore.GetParticipantsParams) ([]pgstore.Participant, error)
 	GetTrip(ctx context.Context, tripID uuid.UUID) (pgstore.Trip, error)
-	GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]pgstore.Activity, error)
+	GetTripActivities(ctx context.Context, arg pgstore.GetTripActivitiesParams) ([]pgstore.Activity, error)
+	GetTripActivityOverlaps(ctx context.Context, tripID uuid.UUID) ([]pgstore.GetTripActivityOverlapsRow, error)
+	GetTripImportedUIDs(ctx context.Context, tripID uuid.UUID) ([]string, error)
+	GetTripInviteLinkByCode(ctx context.Context, code string) (pgstore.TripInviteLink, error)
+	GetTripInviteLinks(ctx context.Context, tripID uuid.UUID) ([]pgstore.TripInviteLink, error)
 	GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]pgstore.Link, error)
-
-	InviteParticipantsToTrip(ctx context.Context, arg []pgstore.InviteParticipantsToTripParams) (int64, error)
-
+	GetTripParticipantByEmail(ctx context.Context, arg pgstore.GetTripParticipantByEmailParams) (pgstore.Participant, error)
+
+	ImportActivities(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		tripID uuid.UUID,
+		activities []pgstore.ImportActivityParams,
+	) ([]uuid.UUID, error)
+	InviteParticipants(ctx context.Context, pool *pgxpool.Pool, trip pgstore.Trip, emails []string) ([]pgstore.InviteResult, error)
+	InviteParticipantsInBulk(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		trip pgstore.Trip,
+		invites []pgstore.InviteParticipantsToTripParams,
+	) ([]pgstore.InviteResult, error)
+
+	JoinTrip(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		trip pgstore.Trip,
+		link pgstore.TripInviteLink,
+		invite pgstore.InviteParticipantsToTripParams,
+	) (pgstore.InviteResult, error)
+
+	ListEmailOutbox(ctx context.Context, status string) ([]pgstore.EmailOutbox, error)
+
+	ResendInvite(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		tripID uuid.UUID,
+		participantID uuid.UUID,
+		cooldown time.Duration,
+	) error
+	RescheduleTrip(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		params pgstore.UpdateTripParams,
+		shift time.Duration,
+	) ([]pgstore.Activity, error)
+	RescheduleActivity(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		tripID uuid.UUID,
+		params pgstore.UpdateActivityParams,
+		strict bool,
+	) ([]pgstore.Activity, error)
+	RescheduleActivitySeries(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		trip pgstore.Trip,
+		params pgstore.UpdateActivitySeriesParams,
+		strict bool,
+	) ([]pgstore.Activity, error)
+	ReleaseParticipant(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		tripID uuid.UUID,
+		participantID uuid.UUID,
+		status string,
+	) error
+	RetryEmail(ctx context.Context, emailID uuid.UUID) (int64, error)
+	RevokeTripInviteLink(ctx context.Context, arg pgstore.RevokeTripInviteLinkParams) (int64, error)
+
+	ScheduleActivity(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		params pgstore.CreateActivityParams,
+		strict bool,
+	) (uuid.UUID, []pgstore.Activity, error)
+	ScheduleActivitySeries(
+		ctx context.Context,
+		pool *pgxpool.Pool,
+		series pgstore.InsertActivitySeriesParams,
+		occurrences []pgstore.CreateActivityParams,
+		strict bool,
+	) (uuid.UUID, []uuid.UUID, []pgstore.Activity, error)
+
+	TransferTripOwnership(ctx context.Context, pool *pgxpool.Pool, trip pgstore.Trip, newOwner pgstore.Participant) error
+
+	UpdateParticipantRole(ctx context.Context, arg pgstore.UpdateParticipantRoleParams) error
 	UpdateTrip(ctx context.Context, params pgstore.UpdateTripParams) error
+	UseToken(ctx context.Context, arg pgstore.UseTokenParams) (int64, error)
 }
 
-type mailer interface {
-	SendConfirmTripEmailToTripOwner(tripID uuid.UUID) error
+// sessionTTL is how long a session started from a sign-in link lasts.
+const sessionTTL = 30 * 24 * time.Hour
+
+// inviteResendCooldown is how long a participant must wait between two
+// invitation e-mails.
+const inviteResendCooldown = 15 * time.Minute
+
+// loginEmailCooldown is how long an e-mail address must wait between two
+// sign-in links, so that the endpoint, which needs no login, can't be used to
+// flood someone's inbox.
+const loginEmailCooldown = 2 * time.Minute
+
+// participantStatuses are the values accepted by the participants status
+// filter.
+var participantStatuses = map[string]bool{
+	pgstore.ParticipantStatusInvited:    true,
+	pgstore.ParticipantStatusConfirmed:  true,
+	pgstore.ParticipantStatusWaitlisted: true,
+	pgstore.ParticipantStatusDeclined:   true,
+	pgstore.ParticipantStatusRemoved:    true,
 }
 
 type API struct {
@@ -44,13 +180,381 @@ type API struct {
 	logger    *zap.Logger
 	validator *validator.Validate
 	pool      *pgxpool.Pool
-	mailer    mailer
+	tokens    token.Signer
+	admins    map[string]bool
+
+	publicBaseURL string
+}
+
+// NewAPI returns the API handlers. The e-mails in admins are allowed to use
+// the admin endpoints, and the join links are built on top of publicBaseURL.
+func NewAPI(
+	pool *pgxpool.Pool,
+	logger *zap.Logger,
+	tokens token.Signer,
+	admins []string,
+	publicBaseURL string,
+) API {
+	adminSet := make(map[string]bool, len(admins))
+	for _, email := range admins {
+		if email = strings.TrimSpace(email); email != "" {
+			adminSet[strings.ToLower(email)] = true
+		}
+	}
+
+	return API{
+		pgstore.New(pool),
+		logger,
+		newValidator(),
+		pool,
+		tokens,
+		adminSet,
+		strings.TrimSuffix(publicBaseURL, "/"),
+	}
+}
+
+// List outbox e-mails.
+// (GET /admin/emails)
+func (api API) GetAdminEmails(w http.ResponseWriter, r *http.Request, params spec.GetAdminEmailsParams) *spec.Response {
+	if failure := api.authorizeAdmin(r.Context()); failure != nil {
+		if failure.unauthenticated {
+			return spec.GetAdminEmailsJSON401Response(failure.err)
+		}
+		return spec.GetAdminEmailsJSON403Response(failure.err)
+	}
+
+	status := pgstore.EmailStatusDead
+	if params.Status != nil {
+		status = *params.Status
+	}
+
+	emails, err := api.store.ListEmailOutbox(r.Context(), status)
+	if err != nil {
+		api.logger.Error("failed to list email outbox", zap.Error(err), zap.String("status", status))
+		return spec.GetAdminEmailsJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	var responseEmails = []spec.EmailOutboxItem{}
+	for _, v := range emails {
+		item := spec.EmailOutboxItem{
+			Attempts:      int(v.Attempts),
+			CreatedAt:     v.CreatedAt.Time,
+			ID:            v.ID.String(),
+			Kind:          v.Kind,
+			NextAttemptAt: v.NextAttemptAt.Time,
+			Status:        v.Status,
+		}
+
+		if v.TripID.Valid {
+			tripID := uuid.UUID(v.TripID.Bytes).String()
+			item.TripID = &tripID
+		}
+
+		if v.LastError.Valid {
+			lastError := v.LastError.String
+			item.LastError = &lastError
+		}
+
+		if v.ParticipantID.Valid {
+			participantID := uuid.UUID(v.ParticipantID.Bytes).String()
+			item.ParticipantID = &participantID
+		}
+
+		if v.Recipient.Valid {
+			recipient := types.Email(v.Recipient.String)
+			item.Recipient = &recipient
+		}
+
+		if v.SentAt.Valid {
+			sentAt := v.SentAt.Time
+			item.SentAt = &sentAt
+		}
+
+		responseEmails = append(responseEmails, item)
+	}
+
+	return spec.GetAdminEmailsJSON200Response(
+		spec.GetEmailOutboxResponse{
+			Emails: responseEmails,
+		},
+	)
+}
+
+// Retry a dead outbox e-mail.
+// (POST /admin/emails/{emailId}/retry)
+func (api API) PostAdminEmailsEmailIDRetry(w http.ResponseWriter, r *http.Request, emailID string) *spec.Response {
+	if failure := api.authorizeAdmin(r.Context()); failure != nil {
+		if failure.unauthenticated {
+			return spec.PostAdminEmailsEmailIDRetryJSON401Response(failure.err)
+		}
+		return spec.PostAdminEmailsEmailIDRetryJSON403Response(failure.err)
+	}
+
+	id, err := uuid.Parse(emailID)
+	if err != nil {
+		return spec.PostAdminEmailsEmailIDRetryJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	retried, err := api.store.RetryEmail(r.Context(), id)
+	if err != nil {
+		api.logger.Error("failed to retry email", zap.Error(err), zap.String("email_id", emailID))
+		return spec.PostAdminEmailsEmailIDRetryJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if retried == 0 {
+		return spec.PostAdminEmailsEmailIDRetryJSON404Response(
+			spec.Error{Message: "dead email not found"},
+		)
+	}
+
+	return spec.PostAdminEmailsEmailIDRetryJSON204Response(nil)
+}
+
+// Send a sign-in link by e-mail.
+// (POST /auth/magic-link)
+func (api API) PostAuthMagicLink(w http.ResponseWriter, r *http.Request) *spec.Response {
+	var body spec.MagicLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PostAuthMagicLinkJSON400Response(
+			spec.Error{Message: "invalid JSON: " + err.Error()},
+		)
+	}
+
+	if verr := api.validate(r.Context(), body); verr != nil {
+		return spec.PostAuthMagicLinkJSON400Response(*verr)
+	}
+
+	recipient := pgtype.Text{Valid: true, String: strings.ToLower(string(body.Email))}
+
+	lastSentAt, err := api.store.GetLastLoginEmailAt(r.Context(), recipient)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		api.logger.Error("failed to get last login email", zap.Error(err))
+		return spec.PostAuthMagicLinkJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if lastSentAt.Valid {
+		if wait := time.Until(lastSentAt.Time.Add(loginEmailCooldown)); wait > 0 {
+			seconds := retryAfter(w, wait)
+			return spec.PostAuthMagicLinkJSON429Response(
+				spec.Error{Message: fmt.Sprintf("sign-in link sent recently, try again in %d seconds", seconds)},
+			)
+		}
+	}
+
+	if err := api.store.EnqueueEmail(r.Context(), pgstore.EnqueueEmailParams{
+		Kind:      pgstore.EmailKindLogin,
+		Recipient: recipient,
+	}); err != nil {
+		api.logger.Error("failed to enqueue login email", zap.Error(err))
+		return spec.PostAuthMagicLinkJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	return spec.PostAuthMagicLinkJSON204Response(nil)
+}
+
+// Start a session from a sign-in link.
+// (GET /auth/session)
+func (api API) GetAuthSession(w http.ResponseWriter, r *http.Request, params spec.GetAuthSessionParams) *spec.Response {
+	claims, err := api.tokens.Verify(params.Token)
+	if err != nil {
+		return spec.GetAuthSessionJSON400Response(tokenError(err))
+	}
+
+	if claims.Purpose != token.PurposeLogin {
+		return spec.GetAuthSessionJSON400Response(spec.Error{Message: "invalid token"})
+	}
+
+	used, err := api.store.UseToken(r.Context(), useTokenParams(claims))
+	if err != nil {
+		api.logger.Error("failed to use login token", zap.Error(err))
+		return spec.GetAuthSessionJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if used == 0 {
+		return spec.GetAuthSessionJSON400Response(spec.Error{Message: "token already used"})
+	}
+
+	session, err := api.tokens.IssueForEmail(token.PurposeSession, claims.Email, sessionTTL)
+	if err != nil {
+		api.logger.Error("failed to issue session token", zap.Error(err))
+		return spec.GetAuthSessionJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	expiresAt := time.Now().Add(sessionTTL)
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookie,
+		Value:    session,
+		Path:     "/",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return spec.GetAuthSessionJSON200Response(
+		spec.SessionResponse{
+			Token:     session,
+			ExpiresAt: expiresAt,
+		},
+	)
+}
+
+// Get a trip calendar through a calendar feed.
+// (GET /calendar/{token}.ics)
+func (api API) GetCalendarTokenIcs(w http.ResponseWriter, r *http.Request, feedToken string) *spec.Response {
+	feed, err := api.store.GetCalendarFeedByToken(r.Context(), strings.ToLower(feedToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.GetCalendarTokenIcsJSON404Response(
+				spec.Error{Message: "calendar feed not found"},
+			)
+		}
+
+		api.logger.Error("failed to get calendar feed", zap.Error(err))
+		return spec.GetCalendarTokenIcsJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), feed.TripID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.GetCalendarTokenIcsJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", feed.TripID.String()))
+		return spec.GetCalendarTokenIcsJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	// The feed goes on working only while whoever it was made for can see the
+	// trip, so that removed participants stop receiving it.
+	role, err := api.tripRole(r.Context(), trip, auth.Caller{Email: feed.Email})
+	if err != nil {
+		api.logger.Error("failed to get caller role", zap.Error(err), zap.String("trip_id", trip.ID.String()))
+		return spec.GetCalendarTokenIcsJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if role == "" {
+		return spec.GetCalendarTokenIcsJSON404Response(
+			spec.Error{Message: "calendar feed not found"},
+		)
+	}
+
+	activities, err := api.store.GetTripActivities(r.Context(), pgstore.GetTripActivitiesParams{TripID: trip.ID})
+	if err != nil {
+		api.logger.Error("failed do get trip activities", zap.Error(err), zap.String("trip_id", trip.ID.String()))
+		return spec.GetCalendarTokenIcsJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	serveCalendar(w, trip, activities)
+	return nil
+}
+
+// Open the page to confirm a trip or a participant from an e-mail link.
+// (GET /confirm)
+func (api API) GetConfirm(w http.ResponseWriter, r *http.Request, params spec.GetConfirmParams) *spec.Response {
+	page, status := api.confirmationPage(r.Context(), params.Token)
+	serveConfirmationPage(w, page, status)
+	return nil
+}
+
+// Get the trip of a join link.
+// (GET /join/{code})
+func (api API) GetJoinCode(w http.ResponseWriter, r *http.Request, code string) *spec.Response {
+	_, trip, failure := api.usableJoinLink(r.Context(), code)
+	if failure != nil {
+		if failure.notFound {
+			return spec.GetJoinCodeJSON404Response(failure.err)
+		}
+		return spec.GetJoinCodeJSON400Response(failure.err)
+	}
+
+	loc := trip.Location()
+	return spec.GetJoinCodeJSON200Response(spec.GetJoinLinkResponse{
+		Destination: trip.Destination,
+		EndsAt:      trip.EndsAt.Time.In(loc),
+		StartsAt:    trip.StartsAt.Time.In(loc),
+		TripID:      trip.ID.String(),
+	})
 }
 
-func NewAPI(pool *pgxpool.Pool, logger *zap.Logger, mailer mailer) API {
-	validator := validator.New(validator.WithRequiredStructEnabled())
+// Join a trip through a join link.
+// (POST /join/{code})
+func (api API) PostJoinCode(w http.ResponseWriter, r *http.Request, code string) *spec.Response {
+	var body spec.JoinTripRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PostJoinCodeJSON400Response(
+			spec.Error{Message: "invalid JSON: " + err.Error()},
+		)
+	}
+
+	if verr := api.validate(r.Context(), body); verr != nil {
+		return spec.PostJoinCodeJSON400Response(*verr)
+	}
+
+	link, trip, failure := api.usableJoinLink(r.Context(), code)
+	if failure != nil {
+		if failure.notFound {
+			return spec.PostJoinCodeJSON404Response(failure.err)
+		}
+		return spec.PostJoinCodeJSON400Response(failure.err)
+	}
+
+	result, err := api.store.JoinTrip(r.Context(), api.pool, trip, link, pgstore.InviteParticipantsToTripParams{
+		Email: string(body.Email),
+		Name:  pgtype.Text{Valid: true, String: strings.TrimSpace(body.Name)},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgstore.ErrInviteLinkUnusable):
+			return spec.PostJoinCodeJSON400Response(
+				spec.Error{Message: "join link can't be used anymore"},
+			)
+		case errors.Is(err, pgstore.ErrInviteConflict):
+			return spec.PostJoinCodeJSON400Response(
+				spec.Error{Message: "this e-mail was invited meanwhile, try again"},
+			)
+		}
+
+		api.logger.Error("failed to join trip", zap.Error(err), zap.String("trip_id", trip.ID.String()))
+		return spec.PostJoinCodeJSON400Response(
+			spec.Error{Message: "failed to join trip, try again"},
+		)
+	}
+
+	if result.Outcome == pgstore.InviteOutcomeOwner {
+		return spec.PostJoinCodeJSON400Response(
+			spec.Error{Message: "the trip owner can't join their own trip"},
+		)
+	}
 
-	return API{pgstore.New(pool), logger, validator, pool, mailer}
+	return spec.PostJoinCodeJSON200Response(spec.JoinTripResponse{
+		Outcome:       result.Outcome,
+		ParticipantID: result.ParticipantID.String(),
+		TripID:        trip.ID.String(),
+	})
 }
 
 // Confirms a participant on a trip.
@@ -59,6 +563,7 @@ func (api API) PatchParticipantsParticipantIDConfirm(
 	w http.ResponseWriter,
 	r *http.Request,
 	participantID string,
+	params spec.PatchParticipantsParticipantIDConfirmParams,
 ) *spec.Response {
 	id, err := uuid.Parse(participantID)
 	if err != nil {
@@ -67,34 +572,129 @@ func (api API) PatchParticipantsParticipantIDConfirm(
 		)
 	}
 
+	claims, verr := api.verifyToken(params.Token, token.PurposeConfirmParticipant, id)
+	if verr != nil {
+		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(*verr)
+	}
+
+	var body spec.ConfirmParticipantRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(
+			spec.Error{Message: "invalid JSON: " + err.Error()},
+		)
+	}
+
+	if verr := api.validate(r.Context(), body); verr != nil {
+		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(*verr)
+	}
+
+	participant, failure := api.confirmParticipant(
+		r.Context(),
+		id,
+		claims,
+		pgtype.Text{Valid: true, String: body.Name},
+	)
+	if failure != nil {
+		if failure.notFound {
+			return spec.PatchParticipantsParticipantIDConfirmJSON404Response(failure.err)
+		}
+		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(failure.err)
+	}
+
+	return spec.PatchParticipantsParticipantIDConfirmJSON200Response(spec.ConfirmTokenResponse{
+		ParticipantID: &participantID,
+		Status:        &participant.Status,
+		TripID:        participant.TripID.String(),
+	})
+}
+
+// Declines an invitation to a trip.
+// (PATCH /participants/{participantId}/decline)
+func (api API) PatchParticipantsParticipantIDDecline(
+	w http.ResponseWriter,
+	r *http.Request,
+	participantID string,
+	params spec.PatchParticipantsParticipantIDDeclineParams,
+) *spec.Response {
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return spec.PatchParticipantsParticipantIDDeclineJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
 	participant, err := api.store.GetParticipant(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.PatchParticipantsParticipantIDConfirmJSON400Response(
+			return spec.PatchParticipantsParticipantIDDeclineJSON404Response(
 				spec.Error{Message: "participant not found"},
 			)
 		}
 
 		api.logger.Error("failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(
+		return spec.PatchParticipantsParticipantIDDeclineJSON400Response(
 			spec.Error{Message: "something went wrong, try again"},
 		)
 	}
 
-	if participant.IsConfirmed {
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(
-			spec.Error{Message: "participant ja confirmado"},
+	// The invitation token proves the invitee clicked the e-mail link.
+	// Without it, they must be signed in with the invited address.
+	var claims token.Claims
+	if params.Token != nil {
+		var verr *spec.Error
+		claims, verr = api.verifyToken(*params.Token, token.PurposeConfirmParticipant, id)
+		if verr != nil {
+			return spec.PatchParticipantsParticipantIDDeclineJSON400Response(*verr)
+		}
+	} else {
+		caller, ok := auth.CallerFromContext(r.Context())
+		if !ok {
+			return spec.PatchParticipantsParticipantIDDeclineJSON401Response(errUnauthenticated.err)
+		}
+
+		if !strings.EqualFold(caller.Email, participant.Email) {
+			return spec.PatchParticipantsParticipantIDDeclineJSON403Response(
+				spec.Error{Message: "you can only decline your own invitation"},
+			)
+		}
+	}
+
+	switch participant.Status {
+	case pgstore.ParticipantStatusDeclined:
+		return spec.PatchParticipantsParticipantIDDeclineJSON400Response(
+			spec.Error{Message: "invitation already declined"},
+		)
+	case pgstore.ParticipantStatusRemoved:
+		return spec.PatchParticipantsParticipantIDDeclineJSON400Response(
+			spec.Error{Message: "participant was removed from the trip"},
 		)
 	}
 
-	if err := api.store.ConfirmParticipant(r.Context(), id); err != nil {
-		api.logger.Error("failed to confirm participant", zap.Error(err), zap.String("participant_id", participantID))
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(
+	if params.Token != nil {
+		err = api.store.DeclineParticipantWithToken(r.Context(), api.pool, participant.TripID, id, useTokenParams(claims))
+	} else {
+		err = api.store.ReleaseParticipant(
+			r.Context(),
+			api.pool,
+			participant.TripID,
+			id,
+			pgstore.ParticipantStatusDeclined,
+		)
+	}
+	if err != nil {
+		if errors.Is(err, pgstore.ErrTokenUsed) {
+			return spec.PatchParticipantsParticipantIDDeclineJSON400Response(
+				spec.Error{Message: "token already used"},
+			)
+		}
+
+		api.logger.Error("failed to decline participant", zap.Error(err), zap.String("participant_id", participantID))
+		return spec.PatchParticipantsParticipantIDDeclineJSON400Response(
 			spec.Error{Message: "something went wrong, try again"},
 		)
 	}
 
-	return spec.PatchParticipantsParticipantIDConfirmJSON204Response(nil)
+	return spec.PatchParticipantsParticipantIDDeclineJSON204Response(nil)
 }
 
 // Create a new trip
@@ -105,26 +705,16 @@ func (api API) PostTrips(w http.ResponseWriter, r *http.Request) *spec.Response
 		return spec.PostTripsJSON400Response(spec.Error{Message: "invalid JSON: " + err.Error()})
 	}
 
-	if err := api.validator.Struct(body); err != nil {
-		return spec.PostTripsJSON400Response(spec.Error{Message: "invalid input: " + err.Error()})
+	if verr := api.validate(r.Context(), body); verr != nil {
+		return spec.PostTripsJSON400Response(*verr)
 	}
 
 	tripID, err := api.store.CreateTrip(r.Context(), api.pool, body)
 	if err != nil {
-		api.logger.Error("failed to create trip", zap.Error(err), zap.String("trip: ", fmt.Sprintf("%s", body)))
+		api.logger.Error("failed to create trip", zap.Error(err), zap.String("trip: ", fmt.Sprint(body)))
 		return spec.PostTripsJSON400Response(spec.Error{Message: "failed to create trip, try again"})
 	}
 
-	go func() {
-		if err := api.mailer.SendConfirmTripEmailToTripOwner(tripID); err != nil {
-			api.logger.Error(
-				"failed to send email on PostTrips",
-				zap.Error(err),
-				zap.String("trip_id", tripID.String()),
-			)
-		}
-	}()
-
 	return spec.PostTripsJSON201Response(spec.CreateTripResponse{TripID: tripID.String()})
 }
 
@@ -152,14 +742,31 @@ func (api API) GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID str
 		)
 	}
 
+	if failure := api.authorizeTrip(r.Context(), trip, permViewTrip); failure != nil {
+		if failure.unauthenticated {
+			return spec.GetTripsTripIDJSON401Response(failure.err)
+		}
+		return spec.GetTripsTripIDJSON403Response(failure.err)
+	}
+
+	var maxParticipants *int
+	if trip.MaxParticipants.Valid {
+		mp := int(trip.MaxParticipants.Int32)
+		maxParticipants = &mp
+	}
+
+	loc := trip.Location()
 	return spec.GetTripsTripIDJSON200Response(
 		spec.GetTripDetailsResponse{
 			Trip: spec.GetTripDetailsResponseTripObj{
-				Destination: trip.Destination,
-				EndsAt:      trip.EndsAt.Time,
-				ID:          trip.ID.String(),
-				IsConfirmed: trip.IsConfirmed,
-				StartsAt:    trip.StartsAt.Time,
+				Destination:     trip.Destination,
+				EndsAt:          trip.EndsAt.Time.In(loc),
+				ID:              trip.ID.String(),
+				IsConfirmed:     trip.IsConfirmed,
+				Locale:          trip.Locale,
+				MaxParticipants: maxParticipants,
+				StartsAt:        trip.StartsAt.Time.In(loc),
+				Timezone:        trip.Timezone,
 			},
 		},
 	)
@@ -189,22 +796,60 @@ func (api API) PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID str
 		)
 	}
 
-	var body pgstore.UpdateTripParams
+	if failure := api.authorizeTrip(r.Context(), trip, permUpdateTrip); failure != nil {
+		if failure.unauthenticated {
+			return spec.PutTripsTripIDJSON401Response(failure.err)
+		}
+		return spec.PutTripsTripIDJSON403Response(failure.err)
+	}
+
+	var body spec.UpdateTripRequest
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		return spec.PutTripsTripIDJSON400Response(
 			spec.Error{Message: "invalid JSON: " + err.Error()},
 		)
 	}
 
-	if err := api.validator.Struct(body); err != nil {
-		return spec.PutTripsTripIDJSON400Response(
-			spec.Error{Message: "invalid input: " + err.Error()},
-		)
+	if verr := api.validate(withTrip(r.Context(), trip), body); verr != nil {
+		return spec.PutTripsTripIDJSON400Response(*verr)
+	}
+
+	params := pgstore.UpdateTripParams{
+		Destination:     body.Destination,
+		EndsAt:          pgtype.Timestamptz{Valid: true, Time: body.EndsAt},
+		StartsAt:        pgtype.Timestamptz{Valid: true, Time: body.StartsAt},
+		IsConfirmed:     trip.IsConfirmed,
+		Timezone:        trip.Timezone,
+		MaxParticipants: trip.MaxParticipants,
+		ID:              id,
+	}
+	if body.Timezone != nil {
+		params.Timezone = *body.Timezone
+	}
+	if body.MaxParticipants != nil {
+		params.MaxParticipants = pgtype.Int4{
+			Valid: *body.MaxParticipants > 0,
+			Int32: int32(*body.MaxParticipants),
+		}
+	}
+
+	var shift time.Duration
+	if body.ShiftActivities != nil && *body.ShiftActivities {
+		shift = body.StartsAt.Sub(trip.StartsAt.Time)
 	}
 
-	body.ID = id
-	if err := api.store.UpdateTrip(r.Context(), body); err != nil {
-		api.logger.Error("failed to update trip", zap.Error(err), zap.String("trip: ", fmt.Sprint(body)))
+	outside, err := api.store.RescheduleTrip(r.Context(), api.pool, params, shift)
+	if err != nil {
+		if errors.Is(err, pgstore.ErrActivitiesOutsideTrip) {
+			return spec.PutTripsTripIDJSON409Response(
+				spec.UpdateTripConflictResponse{
+					Message:    "some activities would fall outside the trip dates",
+					Activities: activityResponses(trip, outside),
+				},
+			)
+		}
+
+		api.logger.Error("failed to update trip", zap.Error(err), zap.String("trip: ", fmt.Sprint(params)))
 		return spec.PutTripsTripIDJSON400Response(
 			spec.Error{Message: "failed to update trip, try again"},
 		)
@@ -217,7 +862,12 @@ func (api API) PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID str
 
 // Get a trip activities.
 // (GET /trips/{tripId}/activities)
-func (api API) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+func (api API) GetTripsTripIDActivities(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	params spec.GetTripsTripIDActivitiesParams,
+) *spec.Response {
 	id, err := uuid.Parse(tripID)
 	if err != nil {
 		return spec.GetTripsTripIDActivitiesJSON400Response(
@@ -225,6 +875,16 @@ func (api API) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request,
 		)
 	}
 
+	var category pgtype.Text
+	if params.Category != nil {
+		if !activityCategories[*params.Category] {
+			return spec.GetTripsTripIDActivitiesJSON400Response(
+				spec.Error{Message: "category must be one of: food transport lodging sightseeing other"},
+			)
+		}
+		category = pgtype.Text{Valid: true, String: *params.Category}
+	}
+
 	trip, err := api.store.GetTrip(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -239,7 +899,17 @@ func (api API) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request,
 		)
 	}
 
-	activities, err := api.store.GetTripActivities(r.Context(), id)
+	if failure := api.authorizeTrip(r.Context(), trip, permViewTrip); failure != nil {
+		if failure.unauthenticated {
+			return spec.GetTripsTripIDActivitiesJSON401Response(failure.err)
+		}
+		return spec.GetTripsTripIDActivitiesJSON403Response(failure.err)
+	}
+
+	activities, err := api.store.GetTripActivities(r.Context(), pgstore.GetTripActivitiesParams{
+		TripID:   id,
+		Category: category,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return spec.GetTripsTripIDActivitiesJSON400Response(
@@ -253,43 +923,74 @@ func (api API) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request,
 		)
 	}
 
-	var responseActivities = []spec.GetTripActivitiesResponseInnerArray{}
-	for _, activity := range activities {
-		responseActivities = append(
-			responseActivities,
-			spec.GetTripActivitiesResponseInnerArray{
-				ID:       activity.ID.String(),
-				OccursAt: activity.OccursAt.Time,
-				Title:    activity.Title,
-			},
-		)
-	}
-
 	return spec.GetTripsTripIDActivitiesJSON200Response(
 		spec.GetTripActivitiesResponse{
-			Activities: []spec.GetTripActivitiesResponseOuterArray{
-				{
-					Activities: responseActivities,
-					Date:       trip.StartsAt.Time,
-				},
-			},
+			Activities: groupActivitiesByDay(trip, activities),
 		},
 	)
 }
 
-// Create a trip activity.
-// (POST /trips/{tripId}/activities)
-func (api API) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	id, err := uuid.Parse(tripID)
-	if err != nil {
-		return spec.PostTripsTripIDActivitiesJSON400Response(
-			spec.Error{Message: "uuid invalid"},
-		)
+// groupActivitiesByDay returns one entry per calendar day, in the trip's time
+// zone, between its starts_at and ends_at (inclusive), even days without
+// activities, each one holding its activities sorted by occurs_at.
+func groupActivitiesByDay(
+	trip pgstore.Trip,
+	activities []pgstore.Activity,
+) []spec.GetTripActivitiesResponseOuterArray {
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].OccursAt.Time.Before(activities[j].OccursAt.Time)
+	})
+
+	loc := trip.Location()
+	firstDay := truncateToDay(trip.StartsAt.Time.In(loc))
+	lastDay := truncateToDay(trip.EndsAt.Time.In(loc))
+
+	days := []spec.GetTripActivitiesResponseOuterArray{}
+	index := map[time.Time]int{}
+	for day := firstDay; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		index[day] = len(days)
+		days = append(days, spec.GetTripActivitiesResponseOuterArray{
+			Activities: []spec.GetTripActivitiesResponseInnerArray{},
+			Date:       day,
+		})
 	}
 
-	if _, err := api.store.GetTrip(r.Context(), id); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.PostTripsTripIDActivitiesJSON400Response(
+	for _, activity := range activities {
+		i, ok := index[truncateToDay(activity.OccursAt.Time.In(loc))]
+		if !ok {
+			continue
+		}
+
+		days[i].Activities = append(days[i].Activities, activityResponse(trip, activity))
+	}
+
+	return days
+}
+
+func truncateToDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// Create a trip activity.
+// (POST /trips/{tripId}/activities)
+func (api API) PostTripsTripIDActivities(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	params spec.PostTripsTripIDActivitiesParams,
+) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDActivitiesJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.PostTripsTripIDActivitiesJSON400Response(
 				spec.Error{Message: "trip not found"},
 			)
 		}
@@ -300,23 +1001,74 @@ func (api API) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request,
 		)
 	}
 
-	var body = pgstore.CreateActivityParams{}
+	if failure := api.authorizeTrip(r.Context(), trip, permManageActivities); failure != nil {
+		if failure.unauthenticated {
+			return spec.PostTripsTripIDActivitiesJSON401Response(failure.err)
+		}
+		return spec.PostTripsTripIDActivitiesJSON403Response(failure.err)
+	}
+
+	var body = spec.CreateActivityRequest{}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		return spec.PostTripsTripIDActivitiesJSON400Response(
 			spec.Error{Message: "invalid json " + err.Error()},
 		)
 	}
 
-	if err := api.validator.Struct(body); err != nil {
-		return spec.PostTripsTripIDActivitiesJSON400Response(
-			spec.Error{Message: "invalid input " + err.Error()},
+	if verr := api.validate(withTrip(r.Context(), trip), body); verr != nil {
+		return spec.PostTripsTripIDActivitiesJSON400Response(*verr)
+	}
+
+	strict := params.Strict != nil && *params.Strict
+
+	if body.Recurrence != nil {
+		series, activities := newActivitySeries(trip, body)
+		seriesID, activityIDs, overlaps, err := api.store.ScheduleActivitySeries(r.Context(), api.pool, series, activities, strict)
+		if err != nil {
+			if errors.Is(err, pgstore.ErrActivityOverlaps) {
+				return spec.PostTripsTripIDActivitiesJSON409Response(
+					spec.ActivityConflictResponse{
+						Message:   "the activity overlaps other activities of the trip",
+						Conflicts: activityResponses(trip, overlaps),
+					},
+				)
+			}
+
+			api.logger.Error("failed to create an activity series", zap.Error(err), zap.String("series: ", fmt.Sprint(series)))
+			return spec.PostTripsTripIDActivitiesJSON400Response(
+				spec.Error{Message: "failed to create an activity, try again"},
+			)
+		}
+
+		occurrenceIDs := make([]string, len(activityIDs))
+		for i, activityID := range activityIDs {
+			occurrenceIDs[i] = activityID.String()
+		}
+		seriesIDString := seriesID.String()
+
+		return spec.PostTripsTripIDActivitiesJSON201Response(
+			spec.CreateActivityResponse{
+				ActivityID:    occurrenceIDs[0],
+				Conflicts:     activityResponses(trip, overlaps),
+				OccurrenceIDs: occurrenceIDs,
+				SeriesID:      &seriesIDString,
+			},
 		)
 	}
 
-	body.TripID = id
-	activityId, err := api.store.CreateActivity(r.Context(), body)
+	activity := newActivityParams(id, body)
+	activityId, overlaps, err := api.store.ScheduleActivity(r.Context(), api.pool, activity, strict)
 	if err != nil {
-		api.logger.Error("failed to create an activity", zap.Error(err), zap.String("activity: ", fmt.Sprint(body)))
+		if errors.Is(err, pgstore.ErrActivityOverlaps) {
+			return spec.PostTripsTripIDActivitiesJSON409Response(
+				spec.ActivityConflictResponse{
+					Message:   "the activity overlaps other activities of the trip",
+					Conflicts: activityResponses(trip, overlaps),
+				},
+			)
+		}
+
+		api.logger.Error("failed to create an activity", zap.Error(err), zap.String("activity: ", fmt.Sprint(activity)))
 		return spec.PostTripsTripIDActivitiesJSON400Response(
 			spec.Error{Message: "failed to create an activity, try again"},
 		)
@@ -325,212 +1077,1466 @@ func (api API) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request,
 	return spec.PostTripsTripIDActivitiesJSON201Response(
 		spec.CreateActivityResponse{
 			ActivityID: activityId.String(),
+			Conflicts:  activityResponses(trip, overlaps),
 		},
 	)
 }
 
-// Confirm a trip and send e-mail invitations.
-// (GET /trips/{tripId}/confirm)
-func (api API) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	panic("not implemented") // TODO: Implement
-}
-
-// Invite someone to the trip.
-// (POST /trips/{tripId}/invites)
-func (api API) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+// List the overlapping activities of a trip.
+// (GET /trips/{tripId}/activities/conflicts)
+func (api API) GetTripsTripIDActivitiesConflicts(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
 	id, err := uuid.Parse(tripID)
 	if err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(
+		return spec.GetTripsTripIDActivitiesConflictsJSON400Response(
 			spec.Error{Message: "uuid invalid"},
 		)
 	}
 
-	if _, err := api.store.GetTrip(r.Context(), id); err != nil {
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.PostTripsTripIDInvitesJSON400Response(
+			return spec.GetTripsTripIDActivitiesConflictsJSON404Response(
 				spec.Error{Message: "trip not found"},
 			)
 		}
 
 		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.PostTripsTripIDInvitesJSON400Response(
+		return spec.GetTripsTripIDActivitiesConflictsJSON400Response(
 			spec.Error{Message: "something went wrong, try again"},
 		)
 	}
 
-	var body = pgstore.InviteParticipantsToTripParams{}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(
-			spec.Error{Message: "invalid json " + err.Error()},
-		)
+	if failure := api.authorizeTrip(r.Context(), trip, permViewTrip); failure != nil {
+		if failure.unauthenticated {
+			return spec.GetTripsTripIDActivitiesConflictsJSON401Response(failure.err)
+		}
+		return spec.GetTripsTripIDActivitiesConflictsJSON403Response(failure.err)
 	}
 
-	if err := api.validator.Struct(body); err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(
-			spec.Error{Message: "invalid input " + err.Error()},
+	overlaps, err := api.store.GetTripActivityOverlaps(r.Context(), trip.ID)
+	if err != nil {
+		api.logger.Error("failed to get activity overlaps", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDActivitiesConflictsJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
 		)
 	}
 
-	body.TripID = id
-	if _, err = api.store.InviteParticipantsToTrip(
-		r.Context(),
-		[]pgstore.InviteParticipantsToTripParams{
-			body,
-		},
-	); err != nil {
-		api.logger.Error("failed to invite participant", zap.Error(err), zap.String("activity: ", fmt.Sprint(body)))
-		return spec.PostTripsTripIDInvitesJSON400Response(
-			spec.Error{Message: "failed to invite participant, try again"},
+	activities, err := api.store.GetTripActivities(r.Context(), pgstore.GetTripActivitiesParams{TripID: trip.ID})
+	if err != nil {
+		api.logger.Error("failed do get trip activities", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDActivitiesConflictsJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
 		)
 	}
 
-	return spec.PostTripsTripIDInvitesJSON201Response(nil)
+	byID := make(map[uuid.UUID]pgstore.Activity, len(activities))
+	for _, activity := range activities {
+		byID[activity.ID] = activity
+	}
+
+	conflicts := make([]spec.ActivityConflict, 0, len(overlaps))
+	for _, overlap := range overlaps {
+		first, firstFound := byID[overlap.FirstID]
+		second, secondFound := byID[overlap.SecondID]
+		if !firstFound || !secondFound {
+			// Deleted between the two queries.
+			continue
+		}
+
+		conflicts = append(conflicts, spec.ActivityConflict{
+			First:  activityResponse(trip, first),
+			Second: activityResponse(trip, second),
+		})
+	}
+
+	return spec.GetTripsTripIDActivitiesConflictsJSON200Response(
+		spec.GetActivityConflictsResponse{Conflicts: conflicts},
+	)
 }
 
-// Get a trip links.
-// (GET /trips/{tripId}/links)
-func (api API) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+// Import trip activities from an iCalendar file.
+// (POST /trips/{tripId}/activities/import)
+func (api API) PostTripsTripIDActivitiesImport(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	params spec.PostTripsTripIDActivitiesImportParams,
+) *spec.Response {
 	id, err := uuid.Parse(tripID)
 	if err != nil {
-		return spec.GetTripsTripIDLinksJSON400Response(
+		return spec.PostTripsTripIDActivitiesImportJSON400Response(
 			spec.Error{Message: "uuid invalid"},
 		)
 	}
 
-	if _, err := api.store.GetTrip(r.Context(), id); err != nil {
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDLinksJSON400Response(
+			return spec.PostTripsTripIDActivitiesImportJSON404Response(
 				spec.Error{Message: "trip not found"},
 			)
 		}
 
 		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.GetTripsTripIDLinksJSON400Response(
+		return spec.PostTripsTripIDActivitiesImportJSON400Response(
 			spec.Error{Message: "something went wrong, try again"},
 		)
 	}
 
-	links, err := api.store.GetTripLinks(r.Context(), id)
+	if failure := api.authorizeTrip(r.Context(), trip, permManageActivities); failure != nil {
+		if failure.unauthenticated {
+			return spec.PostTripsTripIDActivitiesImportJSON401Response(failure.err)
+		}
+		return spec.PostTripsTripIDActivitiesImportJSON403Response(failure.err)
+	}
+
+	events, derr := decodeCalendarImport(w, r, trip)
+	if derr != nil {
+		return spec.PostTripsTripIDActivitiesImportJSON400Response(*derr)
+	}
+
+	if len(events) == 0 {
+		return spec.PostTripsTripIDActivitiesImportJSON400Response(
+			spec.Error{Message: "the calendar has no events"},
+		)
+	}
+
+	imported, err := api.store.GetTripImportedUIDs(r.Context(), trip.ID)
+	if err != nil {
+		api.logger.Error("failed to get imported events", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDActivitiesImportJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	plan := api.planCalendarImport(r.Context(), trip, events, imported)
+	if params.DryRun != nil && *params.DryRun {
+		return spec.PostTripsTripIDActivitiesImportJSON200Response(importResponse(trip, plan, nil, true))
+	}
+
+	activities := make([]pgstore.ImportActivityParams, len(plan.activities))
+	for i, body := range plan.activities {
+		activities[i] = pgstore.ImportActivityParams{
+			Key:      plan.keys[i],
+			Activity: newActivityParams(trip.ID, body),
+		}
+	}
+
+	var ids []uuid.UUID
+	if len(activities) > 0 {
+		ids, err = api.store.ImportActivities(r.Context(), api.pool, trip.ID, activities)
+		if err != nil {
+			if errors.Is(err, pgstore.ErrActivityImported) {
+				return spec.PostTripsTripIDActivitiesImportJSON400Response(
+					spec.Error{Message: "some of the events were imported meanwhile, try again"},
+				)
+			}
+
+			api.logger.Error("failed to import activities", zap.Error(err), zap.String("trip_id", tripID))
+			return spec.PostTripsTripIDActivitiesImportJSON400Response(
+				spec.Error{Message: "failed to import activities, try again"},
+			)
+		}
+	}
+
+	return spec.PostTripsTripIDActivitiesImportJSON201Response(importResponse(trip, plan, ids, false))
+}
+
+// Delete a trip activity.
+// (DELETE /trips/{tripId}/activities/{activityId})
+func (api API) DeleteTripsTripIDActivitiesActivityID(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	activityID string,
+	params spec.DeleteTripsTripIDActivitiesActivityIDParams,
+) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.DeleteTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	wholeSeries, ok := seriesScope(params.Scope)
+	if !ok {
+		return spec.DeleteTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "scope must be one of: occurrence series"},
+		)
+	}
+
+	aid, err := uuid.Parse(activityID)
+	if err != nil {
+		return spec.DeleteTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDLinksJSON400Response(
-				spec.Error{Message: "link not found"},
+			return spec.DeleteTripsTripIDActivitiesActivityIDJSON404Response(
+				spec.Error{Message: "trip not found"},
 			)
 		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.DeleteTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
 	}
 
-	var responseLink = []spec.GetLinksResponseArray{}
-	for _, v := range links {
-		responseLink = append(
-			responseLink,
-			spec.GetLinksResponseArray{
-				ID:    v.ID.String(),
-				Title: v.Title,
-				URL:   v.Url,
-			},
+	if failure := api.authorizeTrip(r.Context(), trip, permManageActivities); failure != nil {
+		if failure.unauthenticated {
+			return spec.DeleteTripsTripIDActivitiesActivityIDJSON401Response(failure.err)
+		}
+		return spec.DeleteTripsTripIDActivitiesActivityIDJSON403Response(failure.err)
+	}
+
+	activity, err := api.store.GetActivity(r.Context(), aid)
+	if err != nil || activity.TripID != trip.ID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return spec.DeleteTripsTripIDActivitiesActivityIDJSON404Response(
+				spec.Error{Message: "activity not found"},
+			)
+		}
+
+		api.logger.Error("failed to get activity", zap.Error(err), zap.String("activity_id", activityID))
+		return spec.DeleteTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
 		)
 	}
 
-	return spec.GetTripsTripIDLinksJSON200Response(
-		spec.GetLinksResponse{
-			Links: responseLink,
-		},
-	)
+	if wholeSeries {
+		if !activity.SeriesID.Valid {
+			return spec.DeleteTripsTripIDActivitiesActivityIDJSON400Response(
+				spec.Error{Message: "activity is not part of a series"},
+			)
+		}
+
+		if err := api.store.DeleteActivitySeries(r.Context(), activity.SeriesID.Bytes); err != nil {
+			api.logger.Error("failed to delete activity series", zap.Error(err), zap.String("activity_id", activityID))
+			return spec.DeleteTripsTripIDActivitiesActivityIDJSON400Response(
+				spec.Error{Message: "failed to delete the activity, try again"},
+			)
+		}
+
+		return spec.DeleteTripsTripIDActivitiesActivityIDJSON204Response(nil)
+	}
+
+	if err := api.store.DeleteActivity(r.Context(), activity.ID); err != nil {
+		api.logger.Error("failed to delete activity", zap.Error(err), zap.String("activity_id", activityID))
+		return spec.DeleteTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "failed to delete the activity, try again"},
+		)
+	}
+
+	return spec.DeleteTripsTripIDActivitiesActivityIDJSON204Response(nil)
 }
 
-// Create a trip link.
-// (POST /trips/{tripId}/links)
-func (api API) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+// Update a trip activity.
+// (PUT /trips/{tripId}/activities/{activityId})
+func (api API) PutTripsTripIDActivitiesActivityID(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	activityID string,
+	params spec.PutTripsTripIDActivitiesActivityIDParams,
+) *spec.Response {
 	id, err := uuid.Parse(tripID)
 	if err != nil {
-		return spec.PostTripsTripIDLinksJSON400Response(
+		return spec.PutTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	wholeSeries, ok := seriesScope(params.Scope)
+	if !ok {
+		return spec.PutTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "scope must be one of: occurrence series"},
+		)
+	}
+
+	aid, err := uuid.Parse(activityID)
+	if err != nil {
+		return spec.PutTripsTripIDActivitiesActivityIDJSON400Response(
 			spec.Error{Message: "uuid invalid"},
 		)
 	}
 
-	if _, err := api.store.GetTrip(r.Context(), id); err != nil {
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.PostTripsTripIDLinksJSON400Response(
+			return spec.PutTripsTripIDActivitiesActivityIDJSON404Response(
 				spec.Error{Message: "trip not found"},
 			)
 		}
 
 		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.PostTripsTripIDLinksJSON400Response(
+		return spec.PutTripsTripIDActivitiesActivityIDJSON400Response(
 			spec.Error{Message: "something went wrong, try again"},
 		)
 	}
 
-	var body = pgstore.CreateTripLinkParams{}
+	if failure := api.authorizeTrip(r.Context(), trip, permManageActivities); failure != nil {
+		if failure.unauthenticated {
+			return spec.PutTripsTripIDActivitiesActivityIDJSON401Response(failure.err)
+		}
+		return spec.PutTripsTripIDActivitiesActivityIDJSON403Response(failure.err)
+	}
+
+	activity, err := api.store.GetActivity(r.Context(), aid)
+	if err != nil || activity.TripID != trip.ID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return spec.PutTripsTripIDActivitiesActivityIDJSON404Response(
+				spec.Error{Message: "activity not found"},
+			)
+		}
+
+		api.logger.Error("failed to get activity", zap.Error(err), zap.String("activity_id", activityID))
+		return spec.PutTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	var body spec.UpdateActivityRequest
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		return spec.PostTripsTripIDLinksJSON400Response(
-			spec.Error{Message: "invalid json " + err.Error()},
+		return spec.PutTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "invalid JSON: " + err.Error()},
 		)
 	}
 
-	if err := api.validator.Struct(body); err != nil {
-		return spec.PostTripsTripIDLinksJSON400Response(
-			spec.Error{Message: "invalid input " + err.Error()},
+	if verr := api.validate(withTrip(r.Context(), trip), body); verr != nil {
+		return spec.PutTripsTripIDActivitiesActivityIDJSON400Response(*verr)
+	}
+
+	if wholeSeries && !activity.SeriesID.Valid {
+		return spec.PutTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "activity is not part of a series"},
 		)
 	}
 
-	body.TripID = id
-	linkId, err := api.store.CreateTripLink(r.Context(), body)
+	strict := params.Strict != nil && *params.Strict
+
+	var overlaps []pgstore.Activity
+	if wholeSeries {
+		overlaps, err = api.store.RescheduleActivitySeries(
+			r.Context(),
+			api.pool,
+			trip,
+			activitySeriesParams(activity, createRequest(body)),
+			strict,
+		)
+	} else {
+		fields := newActivityParams(trip.ID, createRequest(body))
+		overlaps, err = api.store.RescheduleActivity(r.Context(), api.pool, trip.ID, pgstore.UpdateActivityParams{
+			Title:        fields.Title,
+			OccursAt:     fields.OccursAt,
+			Timezone:     fields.Timezone,
+			EndsAt:       fields.EndsAt,
+			LocationName: fields.LocationName,
+			Latitude:     fields.Latitude,
+			Longitude:    fields.Longitude,
+			Notes:        fields.Notes,
+			Category:     fields.Category,
+			CostAmount:   fields.CostAmount,
+			CostCurrency: fields.CostCurrency,
+			ID:           activity.ID,
+		}, strict)
+	}
 	if err != nil {
-		api.logger.Error("failed to create a link", zap.Error(err), zap.String("link: ", fmt.Sprint(body)))
-		return spec.PostTripsTripIDLinksJSON400Response(
-			spec.Error{Message: "failed to create a link, try again"},
+		if errors.Is(err, pgstore.ErrActivitiesOutsideTrip) {
+			return spec.PutTripsTripIDActivitiesActivityIDJSON409Response(
+				spec.ActivityConflictResponse{
+					Message:   "some occurrences would fall outside the trip dates",
+					Conflicts: activityResponses(trip, overlaps),
+				},
+			)
+		}
+		if errors.Is(err, pgstore.ErrActivityOverlaps) {
+			return spec.PutTripsTripIDActivitiesActivityIDJSON409Response(
+				spec.ActivityConflictResponse{
+					Message:   "the activity overlaps other activities of the trip",
+					Conflicts: activityResponses(trip, overlaps),
+				},
+			)
+		}
+
+		api.logger.Error("failed to update activity", zap.Error(err), zap.String("activity_id", activityID))
+		return spec.PutTripsTripIDActivitiesActivityIDJSON400Response(
+			spec.Error{Message: "failed to update the activity, try again"},
 		)
 	}
 
-	return spec.PostTripsTripIDLinksJSON201Response(
-		spec.CreateLinkResponse{
-			LinkID: linkId.String(),
+	return spec.PutTripsTripIDActivitiesActivityIDJSON200Response(
+		spec.UpdateActivityResponse{
+			Conflicts: activityResponses(trip, overlaps),
 		},
 	)
 }
 
-// Get a trip participants.
-// (GET /trips/{tripId}/participants)
-func (api API) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+// Get a trip calendar.
+// (GET /trips/{tripId}/calendar.ics)
+func (api API) GetTripsTripIDCalendarIcs(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
 	id, err := uuid.Parse(tripID)
 	if err != nil {
-		return spec.GetTripsTripIDParticipantsJSON400Response(
+		return spec.GetTripsTripIDCalendarIcsJSON400Response(
 			spec.Error{Message: "uuid invalid"},
 		)
 	}
 
-	participants, err := api.store.GetParticipants(r.Context(), id)
+	trip, err := api.store.GetTrip(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDParticipantsJSON400Response(
-				spec.Error{Message: "participants not found"},
+			return spec.GetTripsTripIDCalendarIcsJSON404Response(
+				spec.Error{Message: "trip not found"},
 			)
 		}
 
-		api.logger.Error("failed to get participants", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.GetTripsTripIDJSON400Response(
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDCalendarIcsJSON400Response(
 			spec.Error{Message: "something went wrong, try again"},
 		)
 	}
 
-	var responsePartipants = []spec.GetTripParticipantsResponseArray{}
+	if failure := api.authorizeTrip(r.Context(), trip, permViewTrip); failure != nil {
+		if failure.unauthenticated {
+			return spec.GetTripsTripIDCalendarIcsJSON401Response(failure.err)
+		}
+		return spec.GetTripsTripIDCalendarIcsJSON403Response(failure.err)
+	}
 
-	for _, v := range participants {
-		responsePartipants = append(
-			responsePartipants,
-			spec.GetTripParticipantsResponseArray{
-				Email:       types.Email(v.Email),
-				ID:          v.ID.String(),
-				IsConfirmed: v.IsConfirmed,
-				Name:        nil,
-			},
+	activities, err := api.store.GetTripActivities(r.Context(), pgstore.GetTripActivitiesParams{TripID: trip.ID})
+	if err != nil {
+		api.logger.Error("failed do get trip activities", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDCalendarIcsJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
 		)
 	}
 
-	return spec.GetTripsTripIDParticipantsJSON200Response(
-		spec.GetTripParticipantsResponse{
-			Participants: responsePartipants,
-		},
-	)
+	serveCalendar(w, trip, activities)
+	return nil
+}
+
+// Revoke the caller calendar feed of a trip.
+// (DELETE /trips/{tripId}/calendar/feed)
+func (api API) DeleteTripsTripIDCalendarFeed(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.DeleteTripsTripIDCalendarFeedJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.DeleteTripsTripIDCalendarFeedJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.DeleteTripsTripIDCalendarFeedJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permViewTrip); failure != nil {
+		if failure.unauthenticated {
+			return spec.DeleteTripsTripIDCalendarFeedJSON401Response(failure.err)
+		}
+		return spec.DeleteTripsTripIDCalendarFeedJSON403Response(failure.err)
+	}
+
+	caller, _ := auth.CallerFromContext(r.Context())
+	deleted, err := api.store.DeleteCalendarFeed(r.Context(), pgstore.DeleteCalendarFeedParams{
+		TripID: trip.ID,
+		Email:  caller.Email,
+	})
+	if err != nil {
+		api.logger.Error("failed to delete calendar feed", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.DeleteTripsTripIDCalendarFeedJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if deleted == 0 {
+		return spec.DeleteTripsTripIDCalendarFeedJSON404Response(
+			spec.Error{Message: "calendar feed not found"},
+		)
+	}
+
+	return spec.DeleteTripsTripIDCalendarFeedJSON204Response(nil)
+}
+
+// Get the caller calendar feed of a trip.
+// (POST /trips/{tripId}/calendar/feed)
+func (api API) PostTripsTripIDCalendarFeed(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDCalendarFeedJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.PostTripsTripIDCalendarFeedJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDCalendarFeedJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permViewTrip); failure != nil {
+		if failure.unauthenticated {
+			return spec.PostTripsTripIDCalendarFeedJSON401Response(failure.err)
+		}
+		return spec.PostTripsTripIDCalendarFeedJSON403Response(failure.err)
+	}
+
+	feedToken, err := newCalendarFeedToken()
+	if err != nil {
+		api.logger.Error("failed to generate calendar feed token", zap.Error(err))
+		return spec.PostTripsTripIDCalendarFeedJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	// An e-mail has a single feed per trip, an existing one is returned as is.
+	caller, _ := auth.CallerFromContext(r.Context())
+	feed, err := api.store.CreateCalendarFeed(r.Context(), pgstore.CreateCalendarFeedParams{
+		TripID: trip.ID,
+		Email:  pgstore.NormalizeEmail(caller.Email),
+		Token:  feedToken,
+	})
+	if err != nil {
+		api.logger.Error("failed to create calendar feed", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDCalendarFeedJSON400Response(
+			spec.Error{Message: "failed to create calendar feed, try again"},
+		)
+	}
+
+	return spec.PostTripsTripIDCalendarFeedJSON200Response(api.calendarFeedResponse(feed))
+}
+
+// Confirm a trip and send e-mail invitations.
+// (GET /trips/{tripId}/confirm)
+func (api API) GetTripsTripIDConfirm(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	params spec.GetTripsTripIDConfirmParams,
+) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.GetTripsTripIDConfirmJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	claims, verr := api.verifyToken(params.Token, token.PurposeConfirmTrip, id)
+	if verr != nil {
+		return spec.GetTripsTripIDConfirmJSON400Response(*verr)
+	}
+
+	if failure := api.confirmTrip(r.Context(), id, claims); failure != nil {
+		if failure.notFound {
+			return spec.GetTripsTripIDConfirmJSON404Response(failure.err)
+		}
+		return spec.GetTripsTripIDConfirmJSON400Response(failure.err)
+	}
+
+	return spec.GetTripsTripIDConfirmJSON204Response(nil)
+}
+
+// Invite someone to the trip.
+// (POST /trips/{tripId}/invites)
+func (api API) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDInvitesJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.PostTripsTripIDInvitesJSON400Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDInvitesJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permInvite); failure != nil {
+		if failure.unauthenticated {
+			return spec.PostTripsTripIDInvitesJSON401Response(failure.err)
+		}
+		return spec.PostTripsTripIDInvitesJSON403Response(failure.err)
+	}
+
+	var body spec.InviteParticipantRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PostTripsTripIDInvitesJSON400Response(
+			spec.Error{Message: "invalid json " + err.Error()},
+		)
+	}
+
+	if verr := api.validate(r.Context(), body); verr != nil {
+		return spec.PostTripsTripIDInvitesJSON400Response(*verr)
+	}
+
+	results, err := api.store.InviteParticipants(r.Context(), api.pool, trip, []string{string(body.Email)})
+	if err != nil {
+		api.logger.Error("failed to invite participant", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDInvitesJSON400Response(
+			spec.Error{Message: "failed to invite participant, try again"},
+		)
+	}
+
+	return spec.PostTripsTripIDInvitesJSON200Response(inviteResponse(results))
+}
+
+// Invite many people to the trip.
+// (POST /trips/{tripId}/invites/bulk)
+func (api API) PostTripsTripIDInvitesBulk(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.PostTripsTripIDInvitesBulkJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permInvite); failure != nil {
+		if failure.unauthenticated {
+			return spec.PostTripsTripIDInvitesBulkJSON401Response(failure.err)
+		}
+		return spec.PostTripsTripIDInvitesBulkJSON403Response(failure.err)
+	}
+
+	body, derr := decodeBulkInvite(w, r)
+	if derr != nil {
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(*derr)
+	}
+
+	if verr := api.validate(r.Context(), body); verr != nil {
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(*verr)
+	}
+
+	invites := make([]pgstore.InviteParticipantsToTripParams, len(body.Participants))
+	for i, participant := range body.Participants {
+		invites[i] = pgstore.InviteParticipantsToTripParams{Email: string(participant.Email)}
+		if participant.Name != nil && *participant.Name != "" {
+			invites[i].Name = pgtype.Text{Valid: true, String: *participant.Name}
+		}
+	}
+
+	results, err := api.store.InviteParticipantsInBulk(r.Context(), api.pool, trip, invites)
+	if err != nil {
+		if errors.Is(err, pgstore.ErrInviteConflict) {
+			return spec.PostTripsTripIDInvitesBulkJSON400Response(
+				spec.Error{Message: "some of the e-mails were invited meanwhile, try again"},
+			)
+		}
+
+		api.logger.Error("failed to invite participants", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(
+			spec.Error{Message: "failed to invite participants, try again"},
+		)
+	}
+
+	return spec.PostTripsTripIDInvitesBulkJSON200Response(inviteResponse(results))
+}
+
+// Get a trip join links.
+// (GET /trips/{tripId}/join-links)
+func (api API) GetTripsTripIDJoinLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.GetTripsTripIDJoinLinksJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.GetTripsTripIDJoinLinksJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDJoinLinksJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permInvite); failure != nil {
+		if failure.unauthenticated {
+			return spec.GetTripsTripIDJoinLinksJSON401Response(failure.err)
+		}
+		return spec.GetTripsTripIDJoinLinksJSON403Response(failure.err)
+	}
+
+	links, err := api.store.GetTripInviteLinks(r.Context(), trip.ID)
+	if err != nil {
+		api.logger.Error("failed to get join links", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDJoinLinksJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	response := spec.GetJoinLinksResponse{Links: make([]spec.JoinLink, 0, len(links))}
+	for _, link := range links {
+		response.Links = append(response.Links, api.joinLinkResponse(link))
+	}
+
+	return spec.GetTripsTripIDJoinLinksJSON200Response(response)
+}
+
+// Create a trip join link.
+// (POST /trips/{tripId}/join-links)
+func (api API) PostTripsTripIDJoinLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDJoinLinksJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.PostTripsTripIDJoinLinksJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDJoinLinksJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permInvite); failure != nil {
+		if failure.unauthenticated {
+			return spec.PostTripsTripIDJoinLinksJSON401Response(failure.err)
+		}
+		return spec.PostTripsTripIDJoinLinksJSON403Response(failure.err)
+	}
+
+	var body spec.CreateJoinLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PostTripsTripIDJoinLinksJSON400Response(
+			spec.Error{Message: "invalid JSON: " + err.Error()},
+		)
+	}
+
+	if verr := api.validate(r.Context(), body); verr != nil {
+		return spec.PostTripsTripIDJoinLinksJSON400Response(*verr)
+	}
+
+	code, err := newJoinLinkCode()
+	if err != nil {
+		api.logger.Error("failed to generate join link code", zap.Error(err))
+		return spec.PostTripsTripIDJoinLinksJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	caller, _ := auth.CallerFromContext(r.Context())
+	params := pgstore.CreateTripInviteLinkParams{
+		TripID:    trip.ID,
+		Code:      code,
+		CreatedBy: pgstore.NormalizeEmail(caller.Email),
+	}
+	if body.ExpiresAt != nil {
+		params.ExpiresAt = pgtype.Timestamptz{Valid: true, Time: *body.ExpiresAt}
+	}
+	if body.MaxUses != nil {
+		params.MaxUses = pgtype.Int4{Valid: true, Int32: int32(*body.MaxUses)}
+	}
+
+	link, err := api.store.CreateTripInviteLink(r.Context(), params)
+	if err != nil {
+		api.logger.Error("failed to create join link", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDJoinLinksJSON400Response(
+			spec.Error{Message: "failed to create join link, try again"},
+		)
+	}
+
+	return spec.PostTripsTripIDJoinLinksJSON201Response(api.joinLinkResponse(link))
+}
+
+// Revoke a trip join link.
+// (DELETE /trips/{tripId}/join-links/{linkId})
+func (api API) DeleteTripsTripIDJoinLinksLinkID(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	linkID string,
+) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.DeleteTripsTripIDJoinLinksLinkIDJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	lid, err := uuid.Parse(linkID)
+	if err != nil {
+		return spec.DeleteTripsTripIDJoinLinksLinkIDJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.DeleteTripsTripIDJoinLinksLinkIDJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.DeleteTripsTripIDJoinLinksLinkIDJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permInvite); failure != nil {
+		if failure.unauthenticated {
+			return spec.DeleteTripsTripIDJoinLinksLinkIDJSON401Response(failure.err)
+		}
+		return spec.DeleteTripsTripIDJoinLinksLinkIDJSON403Response(failure.err)
+	}
+
+	revoked, err := api.store.RevokeTripInviteLink(r.Context(), pgstore.RevokeTripInviteLinkParams{
+		ID:     lid,
+		TripID: trip.ID,
+	})
+	if err != nil {
+		api.logger.Error("failed to revoke join link", zap.Error(err), zap.String("link_id", linkID))
+		return spec.DeleteTripsTripIDJoinLinksLinkIDJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if revoked == 0 {
+		return spec.DeleteTripsTripIDJoinLinksLinkIDJSON404Response(
+			spec.Error{Message: "join link not found"},
+		)
+	}
+
+	return spec.DeleteTripsTripIDJoinLinksLinkIDJSON204Response(nil)
+}
+
+// Get a trip links.
+// (GET /trips/{tripId}/links)
+func (api API) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.GetTripsTripIDLinksJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.GetTripsTripIDLinksJSON400Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDLinksJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permViewTrip); failure != nil {
+		if failure.unauthenticated {
+			return spec.GetTripsTripIDLinksJSON401Response(failure.err)
+		}
+		return spec.GetTripsTripIDLinksJSON403Response(failure.err)
+	}
+
+	links, err := api.store.GetTripLinks(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.GetTripsTripIDLinksJSON400Response(
+				spec.Error{Message: "link not found"},
+			)
+		}
+	}
+
+	var responseLink = []spec.GetLinksResponseArray{}
+	for _, v := range links {
+		responseLink = append(
+			responseLink,
+			spec.GetLinksResponseArray{
+				ID:    v.ID.String(),
+				Title: v.Title,
+				URL:   v.Url,
+			},
+		)
+	}
+
+	return spec.GetTripsTripIDLinksJSON200Response(
+		spec.GetLinksResponse{
+			Links: responseLink,
+		},
+	)
+}
+
+// Create a trip link.
+// (POST /trips/{tripId}/links)
+func (api API) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDLinksJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.PostTripsTripIDLinksJSON400Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDLinksJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permManageLinks); failure != nil {
+		if failure.unauthenticated {
+			return spec.PostTripsTripIDLinksJSON401Response(failure.err)
+		}
+		return spec.PostTripsTripIDLinksJSON403Response(failure.err)
+	}
+
+	var body = pgstore.CreateTripLinkParams{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PostTripsTripIDLinksJSON400Response(
+			spec.Error{Message: "invalid json " + err.Error()},
+		)
+	}
+
+	if verr := api.validate(r.Context(), body); verr != nil {
+		return spec.PostTripsTripIDLinksJSON400Response(*verr)
+	}
+
+	body.TripID = id
+	linkId, err := api.store.CreateTripLink(r.Context(), body)
+	if err != nil {
+		api.logger.Error("failed to create a link", zap.Error(err), zap.String("link: ", fmt.Sprint(body)))
+		return spec.PostTripsTripIDLinksJSON400Response(
+			spec.Error{Message: "failed to create a link, try again"},
+		)
+	}
+
+	return spec.PostTripsTripIDLinksJSON201Response(
+		spec.CreateLinkResponse{
+			LinkID: linkId.String(),
+		},
+	)
+}
+
+// Transfer the trip ownership.
+// (POST /trips/{tripId}/owner)
+func (api API) PostTripsTripIDOwner(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDOwnerJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.PostTripsTripIDOwnerJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDOwnerJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permTransferOwnership); failure != nil {
+		if failure.unauthenticated {
+			return spec.PostTripsTripIDOwnerJSON401Response(failure.err)
+		}
+		return spec.PostTripsTripIDOwnerJSON403Response(failure.err)
+	}
+
+	var body spec.TransferOwnershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PostTripsTripIDOwnerJSON400Response(
+			spec.Error{Message: "invalid JSON: " + err.Error()},
+		)
+	}
+
+	if verr := api.validate(r.Context(), body); verr != nil {
+		return spec.PostTripsTripIDOwnerJSON400Response(*verr)
+	}
+
+	participantID, err := uuid.Parse(body.ParticipantID)
+	if err != nil {
+		return spec.PostTripsTripIDOwnerJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), participantID)
+	if err != nil || participant.TripID != trip.ID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return spec.PostTripsTripIDOwnerJSON404Response(
+				spec.Error{Message: "participant not found"},
+			)
+		}
+
+		api.logger.Error("failed to get participant", zap.Error(err), zap.String("participant_id", body.ParticipantID))
+		return spec.PostTripsTripIDOwnerJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if participant.Status != pgstore.ParticipantStatusConfirmed {
+		return spec.PostTripsTripIDOwnerJSON400Response(
+			spec.Error{Message: "only confirmed participants can become the owner"},
+		)
+	}
+
+	if err := api.store.TransferTripOwnership(r.Context(), api.pool, trip, participant); err != nil {
+		api.logger.Error("failed to transfer trip ownership", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDOwnerJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	return spec.PostTripsTripIDOwnerJSON204Response(nil)
+}
+
+// Get a trip participants.
+// (GET /trips/{tripId}/participants)
+func (api API) GetTripsTripIDParticipants(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	params spec.GetTripsTripIDParticipantsParams,
+) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.GetTripsTripIDParticipantsJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	var status pgtype.Text
+	if params.Status != nil {
+		if !participantStatuses[*params.Status] {
+			return spec.GetTripsTripIDParticipantsJSON400Response(
+				spec.Error{Message: "status must be one of: invited confirmed waitlisted declined removed"},
+			)
+		}
+		status = pgtype.Text{Valid: true, String: *params.Status}
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.GetTripsTripIDParticipantsJSON400Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDParticipantsJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permViewTrip); failure != nil {
+		if failure.unauthenticated {
+			return spec.GetTripsTripIDParticipantsJSON401Response(failure.err)
+		}
+		return spec.GetTripsTripIDParticipantsJSON403Response(failure.err)
+	}
+
+	participants, err := api.store.GetParticipants(r.Context(), pgstore.GetParticipantsParams{
+		TripID: id,
+		Status: status,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.GetTripsTripIDParticipantsJSON400Response(
+				spec.Error{Message: "participants not found"},
+			)
+		}
+
+		api.logger.Error("failed to get participants", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	var responsePartipants = []spec.GetTripParticipantsResponseArray{}
+
+	for _, v := range participants {
+		var name *string
+		if v.Name.Valid {
+			n := v.Name.String
+			name = &n
+		}
+
+		var confirmedAt *time.Time
+		if v.ConfirmedAt.Valid {
+			ca := v.ConfirmedAt.Time
+			confirmedAt = &ca
+		}
+
+		var invitedAt, lastInvitedAt *time.Time
+		if v.InvitedAt.Valid {
+			ia := v.InvitedAt.Time
+			invitedAt = &ia
+		}
+		if v.LastInvitedAt.Valid {
+			lia := v.LastInvitedAt.Time
+			lastInvitedAt = &lia
+		}
+
+		var waitlistedAt *time.Time
+		if v.WaitlistedAt.Valid {
+			wa := v.WaitlistedAt.Time
+			waitlistedAt = &wa
+		}
+
+		responsePartipants = append(
+			responsePartipants,
+			spec.GetTripParticipantsResponseArray{
+				ConfirmedAt:   confirmedAt,
+				Email:         types.Email(v.Email),
+				ID:            v.ID.String(),
+				InviteCount:   int(v.InviteCount),
+				InvitedAt:     invitedAt,
+				IsConfirmed:   v.Status == pgstore.ParticipantStatusConfirmed,
+				LastInvitedAt: lastInvitedAt,
+				Name:          name,
+				Role:          v.Role,
+				Status:        v.Status,
+				WaitlistedAt:  waitlistedAt,
+			},
+		)
+	}
+
+	return spec.GetTripsTripIDParticipantsJSON200Response(
+		spec.GetTripParticipantsResponse{
+			Participants: responsePartipants,
+		},
+	)
+}
+
+// Remove a participant from a trip.
+// (DELETE /trips/{tripId}/participants/{participantId})
+func (api API) DeleteTripsTripIDParticipantsParticipantID(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	participantID string,
+) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.DeleteTripsTripIDParticipantsParticipantIDJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	pid, err := uuid.Parse(participantID)
+	if err != nil {
+		return spec.DeleteTripsTripIDParticipantsParticipantIDJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.DeleteTripsTripIDParticipantsParticipantIDJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.DeleteTripsTripIDParticipantsParticipantIDJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permRemoveParticipants); failure != nil {
+		if failure.unauthenticated {
+			return spec.DeleteTripsTripIDParticipantsParticipantIDJSON401Response(failure.err)
+		}
+		return spec.DeleteTripsTripIDParticipantsParticipantIDJSON403Response(failure.err)
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), pid)
+	if err != nil || participant.TripID != trip.ID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return spec.DeleteTripsTripIDParticipantsParticipantIDJSON404Response(
+				spec.Error{Message: "participant not found"},
+			)
+		}
+
+		api.logger.Error("failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return spec.DeleteTripsTripIDParticipantsParticipantIDJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if participant.Status == pgstore.ParticipantStatusRemoved {
+		return spec.DeleteTripsTripIDParticipantsParticipantIDJSON400Response(
+			spec.Error{Message: "participant already removed"},
+		)
+	}
+
+	if err := api.store.ReleaseParticipant(
+		r.Context(),
+		api.pool,
+		trip.ID,
+		participant.ID,
+		pgstore.ParticipantStatusRemoved,
+	); err != nil {
+		api.logger.Error("failed to remove participant", zap.Error(err), zap.String("participant_id", participantID))
+		return spec.DeleteTripsTripIDParticipantsParticipantIDJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	return spec.DeleteTripsTripIDParticipantsParticipantIDJSON204Response(nil)
+}
+
+// Send the invitation e-mail again.
+// (POST /trips/{tripId}/participants/{participantId}/resend)
+func (api API) PostTripsTripIDParticipantsParticipantIDResend(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	participantID string,
+) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDParticipantsParticipantIDResendJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	pid, err := uuid.Parse(participantID)
+	if err != nil {
+		return spec.PostTripsTripIDParticipantsParticipantIDResendJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.PostTripsTripIDParticipantsParticipantIDResendJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDParticipantsParticipantIDResendJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permInvite); failure != nil {
+		if failure.unauthenticated {
+			return spec.PostTripsTripIDParticipantsParticipantIDResendJSON401Response(failure.err)
+		}
+		return spec.PostTripsTripIDParticipantsParticipantIDResendJSON403Response(failure.err)
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), pid)
+	if err != nil || participant.TripID != trip.ID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return spec.PostTripsTripIDParticipantsParticipantIDResendJSON404Response(
+				spec.Error{Message: "participant not found"},
+			)
+		}
+
+		api.logger.Error("failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return spec.PostTripsTripIDParticipantsParticipantIDResendJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if !trip.IsConfirmed {
+		return spec.PostTripsTripIDParticipantsParticipantIDResendJSON400Response(
+			spec.Error{Message: "invitations are only sent once the trip is confirmed"},
+		)
+	}
+
+	if participant.Status != pgstore.ParticipantStatusInvited {
+		return spec.PostTripsTripIDParticipantsParticipantIDResendJSON400Response(
+			spec.Error{Message: "only pending invitations can be sent again"},
+		)
+	}
+
+	if participant.LastInvitedAt.Valid {
+		if wait := time.Until(participant.LastInvitedAt.Time.Add(inviteResendCooldown)); wait > 0 {
+			return tooManyInvites(w, wait)
+		}
+	}
+
+	if err := api.store.ResendInvite(r.Context(), api.pool, trip.ID, participant.ID, inviteResendCooldown); err != nil {
+		if errors.Is(err, pgstore.ErrInviteCooldown) {
+			return tooManyInvites(w, inviteResendCooldown)
+		}
+
+		api.logger.Error("failed to resend invite", zap.Error(err), zap.String("participant_id", participantID))
+		return spec.PostTripsTripIDParticipantsParticipantIDResendJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	return spec.PostTripsTripIDParticipantsParticipantIDResendJSON204Response(nil)
+}
+
+func tooManyInvites(w http.ResponseWriter, wait time.Duration) *spec.Response {
+	seconds := retryAfter(w, wait)
+	return spec.PostTripsTripIDParticipantsParticipantIDResendJSON429Response(
+		spec.Error{Message: fmt.Sprintf("invitation sent recently, try again in %d seconds", seconds)},
+	)
+}
+
+// retryAfter sets the Retry-After header of a 429 response to wait, rounded
+// to seconds, and returns it.
+func retryAfter(w http.ResponseWriter, wait time.Duration) int {
+	seconds := int(wait.Round(time.Second) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	return seconds
+}
+
+// Change the role of a participant.
+// (PATCH /trips/{tripId}/participants/{participantId}/role)
+func (api API) PatchTripsTripIDParticipantsParticipantIDRole(
+	w http.ResponseWriter,
+	r *http.Request,
+	tripID string,
+	participantID string,
+) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	pid, err := uuid.Parse(participantID)
+	if err != nil {
+		return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON400Response(
+			spec.Error{Message: "uuid invalid"},
+		)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON404Response(
+				spec.Error{Message: "trip not found"},
+			)
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	if failure := api.authorizeTrip(r.Context(), trip, permManageRoles); failure != nil {
+		if failure.unauthenticated {
+			return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON401Response(failure.err)
+		}
+		return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON403Response(failure.err)
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), pid)
+	if err != nil || participant.TripID != trip.ID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON404Response(
+				spec.Error{Message: "participant not found"},
+			)
+		}
+
+		api.logger.Error("failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	var body spec.UpdateParticipantRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON400Response(
+			spec.Error{Message: "invalid JSON: " + err.Error()},
+		)
+	}
+
+	if verr := api.validate(r.Context(), body); verr != nil {
+		return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON400Response(*verr)
+	}
+
+	if err := api.store.UpdateParticipantRole(r.Context(), pgstore.UpdateParticipantRoleParams{
+		Role: body.Role,
+		ID:   participant.ID,
+	}); err != nil {
+		api.logger.Error("failed to update participant role", zap.Error(err), zap.String("participant_id", participantID))
+		return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON400Response(
+			spec.Error{Message: "something went wrong, try again"},
+		)
+	}
+
+	return spec.PatchTripsTripIDParticipantsParticipantIDRoleJSON204Response(nil)
 }
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"journey/internal/api/spec"
+	"journey/internal/auth"
+	"journey/internal/pgstore"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// authFailure is why a caller was refused. The handlers turn it into their own
+// 401 or 403 response.
+type authFailure struct {
+	unauthenticated bool
+	err             spec.Error
+}
+
+var errUnauthenticated = &authFailure{
+	unauthenticated: true,
+	err:             spec.Error{Message: "authentication required"},
+}
+
+// permission is an action on a trip that depends on the caller's role.
+type permission int
+
+const (
+	permViewTrip permission = iota
+	permUpdateTrip
+	permInvite
+	permRemoveParticipants
+	permManageActivities
+	permManageLinks
+	permManageRoles
+	permTransferOwnership
+)
+
+// rolePermissions is what each role is allowed to do on a trip. The owner can
+// do everything.
+var rolePermissions = map[string]map[permission]bool{
+	pgstore.RoleCoOrganizer: {
+		permViewTrip:           true,
+		permUpdateTrip:         true,
+		permInvite:             true,
+		permRemoveParticipants: true,
+		permManageActivities:   true,
+		permManageLinks:        true,
+	},
+	pgstore.RoleMember: {
+		permViewTrip:         true,
+		permManageActivities: true,
+		permManageLinks:      true,
+	},
+	pgstore.RoleViewer: {
+		permViewTrip: true,
+	},
+}
+
+// tripRole returns the role of the caller on trip: RoleOwner for the trip
+// owner, the participant role for confirmed participants, and an empty
+// string for anyone else.
+func (api API) tripRole(ctx context.Context, trip pgstore.Trip, caller auth.Caller) (string, error) {
+	if strings.EqualFold(caller.Email, trip.OwnerEmail) {
+		return pgstore.RoleOwner, nil
+	}
+
+	participant, err := api.store.GetTripParticipantByEmail(ctx, pgstore.GetTripParticipantByEmailParams{
+		TripID: trip.ID,
+		Email:  caller.Email,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if participant.Status != pgstore.ParticipantStatusConfirmed {
+		return "", nil
+	}
+
+	return participant.Role, nil
+}
+
+// authorizeTrip checks that the caller is the trip owner, or a confirmed
+// participant whose role grants perm.
+func (api API) authorizeTrip(ctx context.Context, trip pgstore.Trip, perm permission) *authFailure {
+	caller, ok := auth.CallerFromContext(ctx)
+	if !ok {
+		return errUnauthenticated
+	}
+
+	role, err := api.tripRole(ctx, trip, caller)
+	if err != nil {
+		api.logger.Error("failed to get caller role", zap.Error(err), zap.String("trip_id", trip.ID.String()))
+		return &authFailure{err: spec.Error{Message: "something went wrong, try again"}}
+	}
+
+	if role == "" {
+		return &authFailure{err: spec.Error{Message: "you are not a participant of this trip"}}
+	}
+
+	if role != pgstore.RoleOwner && !rolePermissions[role][perm] {
+		return &authFailure{err: spec.Error{Message: "your role on this trip doesn't allow this action"}}
+	}
+
+	return nil
+}
+
+// authorizeAdmin checks that the caller is one of the configured admins.
+func (api API) authorizeAdmin(ctx context.Context) *authFailure {
+	caller, ok := auth.CallerFromContext(ctx)
+	if !ok {
+		return errUnauthenticated
+	}
+
+	if !api.admins[strings.ToLower(caller.Email)] {
+		return &authFailure{err: spec.Error{Message: "admin access required"}}
+	}
+
+	return nil
+}
@@ -0,0 +1,380 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"journey/internal/api/spec"
+	"journey/internal/ical"
+	"journey/internal/pgstore"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// calendarFeedTokenSize is the number of random bytes in a calendar feed
+// token. Unlike join link codes they are never typed by hand, and they are all
+// that protects the feed, so they are longer.
+const calendarFeedTokenSize = 20
+
+// newCalendarFeedToken returns a random token for the URL of a calendar feed.
+func newCalendarFeedToken() (string, error) {
+	b := make([]byte, calendarFeedTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return strings.ToLower(joinLinkEncoding.EncodeToString(b)), nil
+}
+
+func (api API) calendarFeedResponse(feed pgstore.CalendarFeed) spec.CalendarFeed {
+	return spec.CalendarFeed{
+		CreatedAt: feed.CreatedAt.Time,
+		URL:       api.publicBaseURL + "/calendar/" + feed.Token + ".ics",
+	}
+}
+
+const (
+	// calendarProductID identifies journey as the producer of its calendars.
+	calendarProductID = "-//journey//trip calendar//EN"
+
+	// calendarRefresh is how often subscribed calendar apps are asked to
+	// fetch the feed again.
+	calendarRefresh = "PT1H"
+
+	calendarDateFormat     = "20060102"
+	calendarDateTimeFormat = "20060102T150405Z"
+
+	// calendarLineSize is the maximum length in bytes of a line, without its
+	// line break, longer ones are folded.
+	calendarLineSize = 75
+)
+
+// serveCalendar writes trip and its activities to w as an iCalendar file.
+func serveCalendar(w http.ResponseWriter, trip pgstore.Trip, activities []pgstore.Activity) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="trip-`+trip.ID.String()+`.ics"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(renderCalendar(trip, activities, time.Now())))
+}
+
+// renderCalendar returns trip and its activities as an iCalendar (RFC 5545),
+// with the trip as an all day event. Events have UIDs derived from the trip and
+// activity ids, so that calendar apps update them when they're imported again.
+func renderCalendar(trip pgstore.Trip, activities []pgstore.Activity, now time.Time) string {
+	var c calendar
+	stamp := now.UTC().Format(calendarDateTimeFormat)
+
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", calendarProductID)
+	c.line("CALSCALE", "GREGORIAN")
+	c.line("METHOD", "PUBLISH")
+	c.line("X-WR-CALNAME", calendarText(trip.Destination))
+	c.line("X-WR-TIMEZONE", trip.Location().String())
+	c.line("REFRESH-INTERVAL;VALUE=DURATION", calendarRefresh)
+	c.line("X-PUBLISHED-TTL", calendarRefresh)
+
+	// All day events end on the day after their last one.
+	loc := trip.Location()
+	c.line("BEGIN", "VEVENT")
+	c.line("UID", "trip-"+trip.ID.String()+"@journey")
+	c.line("DTSTAMP", stamp)
+	c.line("DTSTART;VALUE=DATE", trip.StartsAt.Time.In(loc).Format(calendarDateFormat))
+	c.line("DTEND;VALUE=DATE", trip.EndsAt.Time.In(loc).AddDate(0, 0, 1).Format(calendarDateFormat))
+	c.line("SUMMARY", calendarText(trip.Destination))
+	c.line("TRANSP", "TRANSPARENT")
+	c.line("END", "VEVENT")
+
+	for _, activity := range activities {
+		c.line("BEGIN", "VEVENT")
+		c.line("UID", "activity-"+activity.ID.String()+"@journey")
+		c.line("DTSTAMP", stamp)
+		c.line("DTSTART", activity.OccursAt.Time.UTC().Format(calendarDateTimeFormat))
+		if activity.EndsAt.Valid {
+			c.line("DTEND", activity.EndsAt.Time.UTC().Format(calendarDateTimeFormat))
+		}
+		c.line("SUMMARY", calendarText(activity.Title))
+		if activity.LocationName.Valid {
+			c.line("LOCATION", calendarText(activity.LocationName.String))
+		}
+		if activity.Latitude.Valid && activity.Longitude.Valid {
+			c.line("GEO", strconv.FormatFloat(activity.Latitude.Float64, 'f', -1, 64)+";"+
+				strconv.FormatFloat(activity.Longitude.Float64, 'f', -1, 64))
+		}
+		if activity.Notes.Valid {
+			c.line("DESCRIPTION", calendarText(activity.Notes.String))
+		}
+		c.line("CATEGORIES", calendarText(activity.Category))
+		c.line("END", "VEVENT")
+	}
+
+	c.line("END", "VCALENDAR")
+	return c.String()
+}
+
+// calendar builds an iCalendar content, line by line.
+type calendar struct {
+	strings.Builder
+}
+
+// line adds the content line name:value, folded so that no line is longer
+// than calendarLineSize bytes. Folding never splits a UTF-8 sequence.
+func (c *calendar) line(name, value string) {
+	size := 0
+	for _, r := range name + ":" + value {
+		n := utf8.RuneLen(r)
+		if size+n > calendarLineSize {
+			c.WriteString("\r\n ")
+			size = 1
+		}
+		c.WriteRune(r)
+		size += n
+	}
+	c.WriteString("\r\n")
+}
+
+var calendarTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", "",
+)
+
+// calendarText escapes s to be used as a TEXT value.
+func calendarText(s string) string {
+	return calendarTextEscaper.Replace(s)
+}
+
+const (
+	// maxCalendarImportSize caps the size, in bytes, of an imported calendar.
+	maxCalendarImportSize = 1 << 20
+
+	// maxImportedTitle and maxImportedNotes are the longest title and notes an
+	// imported activity gets, longer ones are cut.
+	maxImportedTitle = 255
+	maxImportedNotes = 2000
+)
+
+// decodeCalendarImport reads the events of the iCalendar body of r. Dates and
+// times without a time zone are read in the trip time zone.
+func decodeCalendarImport(w http.ResponseWriter, r *http.Request, trip pgstore.Trip) ([]ical.Event, *spec.Error) {
+	body := http.MaxBytesReader(w, r.Body, maxCalendarImportSize)
+
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, &spec.Error{Message: "invalid content type: " + err.Error()}
+		}
+		if mediaType != "text/calendar" {
+			return nil, &spec.Error{Message: "unsupported content type, use text/calendar"}
+		}
+	}
+
+	events, err := ical.Parse(body, trip.Location())
+	if err != nil {
+		if errors.Is(err, ical.ErrNotCalendar) {
+			return nil, &spec.Error{Message: "the file is not an iCalendar"}
+		}
+		return nil, &spec.Error{Message: strings.TrimPrefix(err.Error(), "ical: ")}
+	}
+
+	return events, nil
+}
+
+// calendarImport is what importing calendar events into a trip amounts to:
+// the activities to create, along with the UID of the event each one comes
+// from and the key it is recorded under, and the events left out.
+type calendarImport struct {
+	uids       []string
+	keys       []string
+	activities []spec.CreateActivityRequest
+	skipped    []spec.SkippedCalendarEvent
+}
+
+// planCalendarImport maps events to activities of trip. All day events are
+// cut to the trip dates. Events outside the trip dates, which don't make a
+// valid activity, or whose import key is in imported or repeated in the
+// calendar, are skipped.
+func (api API) planCalendarImport(
+	ctx context.Context,
+	trip pgstore.Trip,
+	events []ical.Event,
+	imported []string,
+) calendarImport {
+	alreadyImported := make(map[string]bool, len(imported))
+	for _, key := range imported {
+		alreadyImported[key] = true
+	}
+	planned := make(map[string]bool)
+
+	plan := calendarImport{skipped: []spec.SkippedCalendarEvent{}}
+	for _, event := range events {
+		skip := func(reason string) {
+			plan.skipped = append(plan.skipped, spec.SkippedCalendarEvent{
+				Reason:  reason,
+				Summary: event.Summary,
+				UID:     event.UID,
+			})
+		}
+
+		if event.Start.IsZero() {
+			skip("the event has no valid start")
+			continue
+		}
+
+		key := importKey(event)
+		if alreadyImported[key] {
+			skip("the event was already imported")
+			continue
+		}
+		if planned[key] {
+			if event.UID != "" {
+				skip("another event of the calendar has the same UID")
+			} else {
+				skip("another event of the calendar has the same summary, start and location")
+			}
+			continue
+		}
+
+		if event.AllDay {
+			// All day events end at the start of the day after their last one,
+			// and a hotel stay or a car rental often spans more than the trip:
+			// the activity only keeps the part within the trip.
+			if !event.End.After(event.Start) {
+				event.End = event.Start.AddDate(0, 0, 1)
+			}
+			if event.Start.Before(trip.StartsAt.Time) {
+				event.Start = trip.StartsAt.Time
+			}
+			if event.End.After(trip.EndsAt.Time) {
+				event.End = trip.EndsAt.Time
+			}
+			if !event.End.After(event.Start) {
+				skip("the event is outside the trip dates")
+				continue
+			}
+		}
+
+		body := importedActivity(event)
+		endsAt := body.OccursAt
+		if body.EndsAt != nil {
+			endsAt = *body.EndsAt
+		}
+		if body.OccursAt.Before(trip.StartsAt.Time) || endsAt.After(trip.EndsAt.Time) {
+			skip("the event is outside the trip dates")
+			continue
+		}
+
+		if verr := api.validate(withTrip(ctx, trip), body); verr != nil {
+			reason := verr.Message
+			if len(verr.Details) > 0 {
+				reason = verr.Details[0].Field + " " + verr.Details[0].Message
+			}
+			skip(reason)
+			continue
+		}
+
+		planned[key] = true
+		plan.uids = append(plan.uids, event.UID)
+		plan.keys = append(plan.keys, key)
+		plan.activities = append(plan.activities, body)
+	}
+
+	return plan
+}
+
+// importKey returns the key an event is recorded under once imported, so that
+// importing the calendar again skips it: its UID, or for events without one,
+// a digest of their summary, start and location.
+func importKey(event ical.Event) string {
+	if event.UID != "" {
+		return event.UID
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		event.Summary,
+		event.Start.UTC().Format(time.RFC3339),
+		event.Location,
+	}, "\x00")))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// importedActivity maps a calendar event to the request body of the activity
+// it becomes: the summary is the title and the location and description make
+// the notes.
+func importedActivity(event ical.Event) spec.CreateActivityRequest {
+	body := spec.CreateActivityRequest{
+		OccursAt: event.Start,
+		Title:    truncate(event.Summary, maxImportedTitle),
+	}
+	if event.End.After(event.Start) {
+		endsAt := event.End
+		body.EndsAt = &endsAt
+	}
+	if event.Timezone != "" {
+		timezone := event.Timezone
+		body.Timezone = &timezone
+	}
+
+	var notes []string
+	for _, part := range []string{event.Location, event.Description} {
+		if part != "" {
+			notes = append(notes, part)
+		}
+	}
+	if len(notes) > 0 {
+		joined := truncate(strings.Join(notes, "\n\n"), maxImportedNotes)
+		body.Notes = &joined
+	}
+
+	return body
+}
+
+// importResponse describes the activities of plan, created with ids unless
+// it's a dry run.
+func importResponse(trip pgstore.Trip, plan calendarImport, ids []uuid.UUID, dryRun bool) spec.ImportActivitiesResponse {
+	response := spec.ImportActivitiesResponse{
+		Activities: make([]spec.ImportedActivity, len(plan.activities)),
+		DryRun:     dryRun,
+		Skipped:    plan.skipped,
+	}
+
+	for i, body := range plan.activities {
+		loc := activityLocation(trip, body)
+		activity := spec.ImportedActivity{
+			Notes:    body.Notes,
+			OccursAt: body.OccursAt.In(loc),
+			Timezone: loc.String(),
+			Title:    body.Title,
+			UID:      plan.uids[i],
+		}
+		if body.EndsAt != nil {
+			endsAt := body.EndsAt.In(loc)
+			activity.EndsAt = &endsAt
+		}
+		if i < len(ids) {
+			id := ids[i].String()
+			activity.ID = &id
+		}
+		response.Activities[i] = activity
+	}
+
+	return response
+}
+
+// truncate cuts s to at most n characters.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
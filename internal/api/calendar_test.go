@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"journey/internal/ical"
+	"journey/internal/pgstore"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestPlanCalendarImport(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Lisbon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := func(d, hour int) time.Time {
+		return time.Date(2024, time.June, d, hour, 0, 0, 0, loc)
+	}
+
+	// The trip goes from June 10 at 14:00 to June 14 at 12:00.
+	trip := pgstore.Trip{
+		Destination: "Lisbon",
+		StartsAt:    pgtype.Timestamptz{Valid: true, Time: day(10, 14)},
+		EndsAt:      pgtype.Timestamptz{Valid: true, Time: day(14, 12)},
+		Timezone:    "Europe/Lisbon",
+	}
+
+	tests := []struct {
+		name     string
+		events   []ical.Event
+		imported []string
+		// want is the start and end of each planned activity, and skipped
+		// the reason of each skipped event.
+		want    [][2]time.Time
+		skipped []string
+	}{
+		{
+			name: "event within the trip",
+			events: []ical.Event{
+				{UID: "a", Summary: "Dinner", Start: day(11, 20), End: day(11, 22)},
+			},
+			want: [][2]time.Time{{day(11, 20), day(11, 22)}},
+		},
+		{
+			name: "event outside the trip",
+			events: []ical.Event{
+				{UID: "a", Summary: "Flight", Start: day(10, 8), End: day(10, 10)},
+			},
+			skipped: []string{"the event is outside the trip dates"},
+		},
+		{
+			name: "all day event on the first day is cut to the trip start",
+			events: []ical.Event{
+				{UID: "a", Summary: "Museum", Start: day(10, 0), AllDay: true},
+			},
+			want: [][2]time.Time{{day(10, 14), day(11, 0)}},
+		},
+		{
+			name: "all day event on the last day is cut to the trip end",
+			events: []ical.Event{
+				{UID: "a", Summary: "Beach", Start: day(14, 0), End: day(15, 0), AllDay: true},
+			},
+			want: [][2]time.Time{{day(14, 0), day(14, 12)}},
+		},
+		{
+			name: "all day event spanning more than the trip",
+			events: []ical.Event{
+				{UID: "a", Summary: "Hotel", Start: day(9, 0), End: day(16, 0), AllDay: true},
+			},
+			want: [][2]time.Time{{day(10, 14), day(14, 12)}},
+		},
+		{
+			name: "all day event before the trip",
+			events: []ical.Event{
+				{UID: "a", Summary: "Packing", Start: day(9, 0), End: day(10, 0), AllDay: true},
+			},
+			skipped: []string{"the event is outside the trip dates"},
+		},
+		{
+			name: "event already imported",
+			events: []ical.Event{
+				{UID: "a", Summary: "Dinner", Start: day(11, 20)},
+				{UID: "b", Summary: "Lunch", Start: day(12, 13)},
+			},
+			imported: []string{"a"},
+			want:     [][2]time.Time{{day(12, 13), day(12, 13)}},
+			skipped:  []string{"the event was already imported"},
+		},
+		{
+			name: "events repeated in the calendar",
+			events: []ical.Event{
+				{UID: "a", Summary: "Dinner", Start: day(11, 20)},
+				{UID: "a", Summary: "Dinner", Start: day(11, 20)},
+				{Summary: "Walk", Start: day(12, 10), Location: "Alfama"},
+				{Summary: "Walk", Start: day(12, 10), Location: "Alfama"},
+				{Summary: "Walk", Start: day(12, 10), Location: "Belém"},
+			},
+			want: [][2]time.Time{
+				{day(11, 20), day(11, 20)},
+				{day(12, 10), day(12, 10)},
+				{day(12, 10), day(12, 10)},
+			},
+			skipped: []string{
+				"another event of the calendar has the same UID",
+				"another event of the calendar has the same summary, start and location",
+			},
+		},
+		{
+			name: "event without UID already imported",
+			events: []ical.Event{
+				{Summary: "Walk", Start: day(12, 10), Location: "Alfama"},
+				{Summary: "Walk", Start: day(13, 10), Location: "Alfama"},
+			},
+			imported: []string{importKey(ical.Event{Summary: "Walk", Start: day(12, 10), Location: "Alfama"})},
+			want:     [][2]time.Time{{day(13, 10), day(13, 10)}},
+			skipped:  []string{"the event was already imported"},
+		},
+		{
+			name: "event without start",
+			events: []ical.Event{
+				{UID: "a", Summary: "Dinner"},
+			},
+			skipped: []string{"the event has no valid start"},
+		},
+	}
+
+	api := API{validator: newValidator()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := api.planCalendarImport(context.Background(), trip, tt.events, tt.imported)
+
+			if len(plan.activities) != len(tt.want) {
+				t.Fatalf("planned %d activities, want %d (skipped %v)", len(plan.activities), len(tt.want), plan.skipped)
+			}
+			for i, body := range plan.activities {
+				endsAt := body.OccursAt
+				if body.EndsAt != nil {
+					endsAt = *body.EndsAt
+				}
+				if !body.OccursAt.Equal(tt.want[i][0]) || !endsAt.Equal(tt.want[i][1]) {
+					t.Errorf("activity %d goes from %v to %v, want %v to %v",
+						i, body.OccursAt, endsAt, tt.want[i][0], tt.want[i][1])
+				}
+			}
+
+			if len(plan.skipped) != len(tt.skipped) {
+				t.Fatalf("skipped %v, want %v", plan.skipped, tt.skipped)
+			}
+			for i, skipped := range plan.skipped {
+				if skipped.Reason != tt.skipped[i] {
+					t.Errorf("event %d skipped because %q, want %q", i, skipped.Reason, tt.skipped[i])
+				}
+			}
+		})
+	}
+}
+
+func TestImportKey(t *testing.T) {
+	start := time.Date(2024, time.June, 12, 10, 0, 0, 0, time.UTC)
+	walk := ical.Event{Summary: "Walk", Start: start, Location: "Alfama"}
+
+	if got := importKey(ical.Event{UID: "a", Summary: "Walk", Start: start}); got != "a" {
+		t.Errorf("key of an event with UID is %q, want its UID", got)
+	}
+
+	key := importKey(walk)
+	if key == "" || key != importKey(ical.Event{Summary: "Walk", Start: start.In(time.FixedZone("", 3600)), Location: "Alfama"}) {
+		t.Errorf("the same event without UID has different keys")
+	}
+	for _, other := range []ical.Event{
+		{Summary: "Walk", Start: start.Add(time.Hour), Location: "Alfama"},
+		{Summary: "Walk", Start: start, Location: "Belém"},
+		{Summary: "Tour", Start: start, Location: "Alfama"},
+	} {
+		if importKey(other) == key {
+			t.Errorf("%s at %v in %s has the key of %s at %v in %s",
+				other.Summary, other.Start, other.Location, walk.Summary, walk.Start, walk.Location)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package api
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"html/template"
+	"journey/internal/api/spec"
+	"journey/internal/pgstore"
+	"journey/internal/token"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// confirmFailure is why a confirmation was refused. The handlers turn it into
+// their own 400 or 404 response.
+type confirmFailure struct {
+	notFound bool
+	err      spec.Error
+}
+
+// verifyToken checks that raw is a valid token issued for purpose and
+// subject.
+func (api API) verifyToken(raw, purpose string, subject uuid.UUID) (token.Claims, *spec.Error) {
+	claims, err := api.tokens.Verify(raw)
+	if err != nil {
+		verr := tokenError(err)
+		return token.Claims{}, &verr
+	}
+
+	if claims.Purpose != purpose || claims.Subject != subject {
+		return token.Claims{}, &spec.Error{Message: "invalid token"}
+	}
+
+	return claims, nil
+}
+
+func tokenError(err error) spec.Error {
+	if errors.Is(err, token.ErrExpired) {
+		return spec.Error{Message: "token expired"}
+	}
+	return spec.Error{Message: "invalid token"}
+}
+
+func useTokenParams(claims token.Claims) pgstore.UseTokenParams {
+	return pgstore.UseTokenParams{
+		ID:        claims.ID,
+		ExpiresAt: pgtype.Timestamptz{Valid: true, Time: claims.ExpiresAt},
+	}
+}
+
+// confirmTrip confirms the trip, enqueues the invitations of its participants
+// and spends the token.
+func (api API) confirmTrip(ctx context.Context, tripID uuid.UUID, claims token.Claims) *confirmFailure {
+	trip, failure := api.confirmationTrip(ctx, tripID)
+	if failure != nil {
+		return failure
+	}
+
+	if trip.IsConfirmed {
+		return &confirmFailure{err: spec.Error{Message: "trip already confirmed"}}
+	}
+
+	if err := api.store.ConfirmTripAndInviteParticipants(ctx, api.pool, tripID, useTokenParams(claims)); err != nil {
+		if errors.Is(err, pgstore.ErrTokenUsed) {
+			return &confirmFailure{err: spec.Error{Message: "token already used"}}
+		}
+
+		api.logger.Error("failed to confirm trip", zap.Error(err), zap.String("trip_id", tripID.String()))
+		return &confirmFailure{err: spec.Error{Message: "something went wrong, try again"}}
+	}
+
+	return nil
+}
+
+// unconfirmable returns why participant can't be confirmed, or nil when they
+// can.
+func unconfirmable(participant pgstore.Participant) *confirmFailure {
+	switch participant.Status {
+	case pgstore.ParticipantStatusConfirmed:
+		return &confirmFailure{err: spec.Error{Message: "participant already confirmed"}}
+	case pgstore.ParticipantStatusWaitlisted:
+		return &confirmFailure{err: spec.Error{Message: "participant already on the waitlist"}}
+	case pgstore.ParticipantStatusRemoved:
+		return &confirmFailure{err: spec.Error{Message: "participant was removed from the trip"}}
+	}
+	return nil
+}
+
+// confirmParticipant confirms the participant with the given name and spends
+// the token. The returned participant has the status they ended with, which
+// is waitlisted when the trip was full.
+func (api API) confirmParticipant(
+	ctx context.Context,
+	participantID uuid.UUID,
+	claims token.Claims,
+	name pgtype.Text,
+) (pgstore.Participant, *confirmFailure) {
+	participant, err := api.store.GetParticipant(ctx, participantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgstore.Participant{}, &confirmFailure{notFound: true, err: spec.Error{Message: "participant not found"}}
+		}
+
+		api.logger.Error("failed to get participant", zap.Error(err), zap.String("participant_id", participantID.String()))
+		return pgstore.Participant{}, &confirmFailure{err: spec.Error{Message: "something went wrong, try again"}}
+	}
+
+	if failure := unconfirmable(participant); failure != nil {
+		return participant, failure
+	}
+
+	status, err := api.store.ConfirmParticipantWithToken(ctx, api.pool, participant.TripID, pgstore.ConfirmParticipantParams{
+		Name: name,
+		ID:   participantID,
+	}, useTokenParams(claims))
+	if err != nil {
+		if errors.Is(err, pgstore.ErrTokenUsed) {
+			return participant, &confirmFailure{err: spec.Error{Message: "token already used"}}
+		}
+
+		api.logger.Error("failed to confirm participant", zap.Error(err), zap.String("participant_id", participantID.String()))
+		return participant, &confirmFailure{err: spec.Error{Message: "something went wrong, try again"}}
+	}
+
+	participant.Status = status
+	return participant, nil
+}
+
+//go:embed confirm.html.tmpl
+var confirmPageTemplate string
+
+var confirmPage = template.Must(template.New("confirm").Parse(confirmPageTemplate))
+
+// confirmPageData is what the confirmation page shows. The page submits the
+// confirmation to Action with Method, sending the name when AskName is set.
+type confirmPageData struct {
+	Error       string
+	Destination string
+	AskName     bool
+	Name        string
+	Action      string
+	Method      string
+}
+
+// confirmationPage returns the page opened from the link of a confirmation
+// e-mail, and its status code. It only checks the token: the trip or
+// participant is confirmed when the page is submitted.
+func (api API) confirmationPage(ctx context.Context, raw string) (confirmPageData, int) {
+	failed := func(failure *confirmFailure) (confirmPageData, int) {
+		if failure.notFound {
+			return confirmPageData{Error: failure.err.Message}, http.StatusNotFound
+		}
+		return confirmPageData{Error: failure.err.Message}, http.StatusBadRequest
+	}
+
+	claims, err := api.tokens.Verify(raw)
+	if err != nil {
+		return failed(&confirmFailure{err: tokenError(err)})
+	}
+
+	query := "?" + url.Values{"token": {raw}}.Encode()
+	switch claims.Purpose {
+	case token.PurposeConfirmTrip:
+		trip, failure := api.confirmationTrip(ctx, claims.Subject)
+		if failure != nil {
+			return failed(failure)
+		}
+		if trip.IsConfirmed {
+			return failed(&confirmFailure{err: spec.Error{Message: "trip already confirmed"}})
+		}
+
+		return confirmPageData{
+			Destination: trip.Destination,
+			Action:      api.publicBaseURL + "/trips/" + trip.ID.String() + "/confirm" + query,
+			Method:      http.MethodGet,
+		}, http.StatusOK
+	case token.PurposeConfirmParticipant:
+		participant, err := api.store.GetParticipant(ctx, claims.Subject)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return failed(&confirmFailure{notFound: true, err: spec.Error{Message: "participant not found"}})
+			}
+
+			api.logger.Error("failed to get participant", zap.Error(err), zap.String("participant_id", claims.Subject.String()))
+			return failed(&confirmFailure{err: spec.Error{Message: "something went wrong, try again"}})
+		}
+		if failure := unconfirmable(participant); failure != nil {
+			return failed(failure)
+		}
+
+		trip, failure := api.confirmationTrip(ctx, participant.TripID)
+		if failure != nil {
+			return failed(failure)
+		}
+
+		return confirmPageData{
+			Destination: trip.Destination,
+			AskName:     true,
+			Name:        participant.Name.String,
+			Action:      api.publicBaseURL + "/participants/" + participant.ID.String() + "/confirm" + query,
+			Method:      http.MethodPatch,
+		}, http.StatusOK
+	}
+
+	return failed(&confirmFailure{err: spec.Error{Message: "invalid token"}})
+}
+
+// confirmationTrip returns the trip a confirmation token is about.
+func (api API) confirmationTrip(ctx context.Context, tripID uuid.UUID) (pgstore.Trip, *confirmFailure) {
+	trip, err := api.store.GetTrip(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgstore.Trip{}, &confirmFailure{notFound: true, err: spec.Error{Message: "trip not found"}}
+		}
+
+		api.logger.Error("failed to get trip", zap.Error(err), zap.String("trip_id", tripID.String()))
+		return pgstore.Trip{}, &confirmFailure{err: spec.Error{Message: "something went wrong, try again"}}
+	}
+	return trip, nil
+}
+
+// serveConfirmationPage writes the confirmation page to w.
+func serveConfirmationPage(w http.ResponseWriter, page confirmPageData, status int) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.WriteHeader(status)
+	_ = confirmPage.Execute(w, page)
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"journey/internal/token"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVerifyToken(t *testing.T) {
+	signer, err := token.NewSigner([]byte(strings.Repeat("s", token.MinSecretLength)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := API{tokens: signer}
+
+	participantID := uuid.New()
+	issue := func(purpose string, subject uuid.UUID, ttl time.Duration) string {
+		raw, err := signer.Issue(purpose, subject, ttl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "valid", token: issue(token.PurposeConfirmParticipant, participantID, time.Hour)},
+		{name: "wrong purpose", token: issue(token.PurposeConfirmTrip, participantID, time.Hour), want: "invalid token"},
+		{name: "wrong subject", token: issue(token.PurposeConfirmParticipant, uuid.New(), time.Hour), want: "invalid token"},
+		{name: "expired", token: issue(token.PurposeConfirmParticipant, participantID, -time.Minute), want: "token expired"},
+		{name: "malformed", token: "not a token", want: "invalid token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, verr := api.verifyToken(tt.token, token.PurposeConfirmParticipant, participantID)
+			if tt.want == "" {
+				if verr != nil {
+					t.Fatalf("verifyToken() error = %q", verr.Message)
+				}
+				if claims.Subject != participantID {
+					t.Errorf("verifyToken() subject = %v, want %v", claims.Subject, participantID)
+				}
+				return
+			}
+			if verr == nil || verr.Message != tt.want {
+				t.Errorf("verifyToken() error = %v, want %q", verr, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"journey/internal/api/spec"
+	"journey/internal/contacts"
+	"journey/internal/pgstore"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/discord-gophers/goapi-gen/types"
+	"github.com/google/uuid"
+)
+
+// maxBulkInviteSize caps the size, in bytes, of a bulk invite upload.
+const maxBulkInviteSize = 1 << 20
+
+// decodeBulkInvite reads a bulk invite from a JSON body, a CSV file or a
+// vCard export, according to the request Content-Type. Uploads are turned
+// into a BulkInviteRequest so that every format goes through the same
+// validation.
+func decodeBulkInvite(w http.ResponseWriter, r *http.Request) (spec.BulkInviteRequest, *spec.Error) {
+	body := http.MaxBytesReader(w, r.Body, maxBulkInviteSize)
+
+	mediaType := "application/json"
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		parsed, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return spec.BulkInviteRequest{}, &spec.Error{Message: "invalid content type: " + err.Error()}
+		}
+		mediaType = parsed
+	}
+
+	var (
+		people []contacts.Contact
+		err    error
+	)
+	switch mediaType {
+	case "application/json":
+		people, err = decodeBulkInviteJSON(body)
+	case "text/csv":
+		people, err = contacts.ParseCSV(body)
+	case "text/vcard", "text/x-vcard":
+		people, err = contacts.ParseVCard(body)
+	default:
+		return spec.BulkInviteRequest{}, &spec.Error{
+			Message: "unsupported content type, use application/json, text/csv or text/vcard",
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, contacts.ErrNoEmailColumn) {
+			return spec.BulkInviteRequest{}, &spec.Error{Message: "the csv header must have an email column"}
+		}
+		return spec.BulkInviteRequest{}, &spec.Error{Message: strings.TrimPrefix(err.Error(), "contacts: ")}
+	}
+
+	var request spec.BulkInviteRequest
+	if people != nil {
+		request.Participants = make([]spec.BulkInviteParticipant, len(people))
+	}
+	for i, person := range people {
+		request.Participants[i].Email = types.Email(strings.TrimSpace(person.Email))
+		if person.Name != "" {
+			name := person.Name
+			request.Participants[i].Name = &name
+		}
+	}
+
+	return request, nil
+}
+
+// decodeBulkInviteJSON decodes a BulkInviteRequest body. The e-mails are read
+// as plain strings, since types.Email would reject the whole body on the first
+// invalid address instead of letting validation report each row.
+func decodeBulkInviteJSON(body io.Reader) ([]contacts.Contact, error) {
+	var request struct {
+		Participants []struct {
+			Email string  `json:"email"`
+			Name  *string `json:"name"`
+		} `json:"participants"`
+	}
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if request.Participants == nil {
+		return nil, nil
+	}
+
+	people := make([]contacts.Contact, len(request.Participants))
+	for i, participant := range request.Participants {
+		people[i].Email = participant.Email
+		if participant.Name != nil {
+			people[i].Name = strings.TrimSpace(*participant.Name)
+		}
+	}
+
+	return people, nil
+}
+
+func inviteResponse(results []pgstore.InviteResult) spec.InviteParticipantsResponse {
+	response := spec.InviteParticipantsResponse{
+		Results: make([]spec.InviteParticipantResult, 0, len(results)),
+	}
+
+	for _, result := range results {
+		var participantID *string
+		if result.ParticipantID != uuid.Nil {
+			id := result.ParticipantID.String()
+			participantID = &id
+		}
+
+		response.Results = append(response.Results, spec.InviteParticipantResult{
+			Email:         types.Email(result.Email),
+			Outcome:       result.Outcome,
+			ParticipantID: participantID,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBulkInviteDuplicates(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		// want are the fields reported as duplicated.
+		want []string
+	}{
+		{
+			name:        "csv",
+			contentType: "text/csv",
+			body:        "name,email\nAna,ana@example.com\nAna again,ANA@example.com \nBruno,bruno@example.com\n",
+			want:        []string{"participants[1].email"},
+		},
+		{
+			name:        "vcard",
+			contentType: "text/vcard",
+			body: "BEGIN:VCARD\nFN:Ana\nEMAIL:ana@example.com\nEND:VCARD\n" +
+				"BEGIN:VCARD\nFN:Bruno\nEMAIL:bruno@example.com\nEND:VCARD\n" +
+				"BEGIN:VCARD\nFN:Ana\nEMAIL:Ana@Example.com\nEND:VCARD\n",
+			want: []string{"participants[2].email"},
+		},
+		{
+			name:        "json",
+			contentType: "application/json",
+			body:        `{"participants":[{"email":"ana@example.com"},{"email":"ana@example.com"},{"email":"ana@example.com"}]}`,
+			want:        []string{"participants[1].email", "participants[2].email"},
+		},
+		{
+			name:        "no duplicates",
+			contentType: "text/csv; charset=utf-8",
+			body:        "email\nana@example.com\nbruno@example.com\n",
+		},
+	}
+
+	api := API{validator: newValidator()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/trips/1/invites/bulk", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+
+			body, derr := decodeBulkInvite(w, r)
+			if derr != nil {
+				t.Fatalf("decodeBulkInvite() error = %q", derr.Message)
+			}
+
+			var got []string
+			if verr := api.validate(context.Background(), body); verr != nil {
+				for _, detail := range verr.Details {
+					if detail.Message != "is duplicated" {
+						t.Errorf("%s %s", detail.Field, detail.Message)
+						continue
+					}
+					got = append(got, detail.Field)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("duplicated fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
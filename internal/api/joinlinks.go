@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"journey/internal/api/spec"
+	"journey/internal/pgstore"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// joinLinkCodeSize is the number of random bytes in a join link code, which
+// gives 16 characters once encoded.
+const joinLinkCodeSize = 10
+
+var joinLinkEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newJoinLinkCode returns a random code, short enough to be shared by hand.
+func newJoinLinkCode() (string, error) {
+	b := make([]byte, joinLinkCodeSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return strings.ToLower(joinLinkEncoding.EncodeToString(b)), nil
+}
+
+// joinFailure is why a join link can't be used. The handlers turn it into
+// their own 400 or 404 response.
+type joinFailure struct {
+	notFound bool
+	err      spec.Error
+}
+
+// usableJoinLink returns the join link with code, and its trip, when the link
+// can still be used to join the trip.
+func (api API) usableJoinLink(ctx context.Context, code string) (pgstore.TripInviteLink, pgstore.Trip, *joinFailure) {
+	link, err := api.store.GetTripInviteLinkByCode(ctx, strings.ToLower(code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return link, pgstore.Trip{}, &joinFailure{notFound: true, err: spec.Error{Message: "join link not found"}}
+		}
+
+		api.logger.Error("failed to get join link", zap.Error(err), zap.String("code", code))
+		return link, pgstore.Trip{}, &joinFailure{err: spec.Error{Message: "something went wrong, try again"}}
+	}
+
+	if link.RevokedAt.Valid {
+		return link, pgstore.Trip{}, &joinFailure{notFound: true, err: spec.Error{Message: "join link not found"}}
+	}
+
+	if link.ExpiresAt.Valid && link.ExpiresAt.Time.Before(time.Now()) {
+		return link, pgstore.Trip{}, &joinFailure{err: spec.Error{Message: "join link expired"}}
+	}
+
+	if link.MaxUses.Valid && link.Uses >= link.MaxUses.Int32 {
+		return link, pgstore.Trip{}, &joinFailure{err: spec.Error{Message: "join link reached its maximum uses"}}
+	}
+
+	trip, err := api.store.GetTrip(ctx, link.TripID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return link, trip, &joinFailure{notFound: true, err: spec.Error{Message: "trip not found"}}
+		}
+
+		api.logger.Error("failed do get trip", zap.Error(err), zap.String("trip_id", link.TripID.String()))
+		return link, trip, &joinFailure{err: spec.Error{Message: "something went wrong, try again"}}
+	}
+
+	return link, trip, nil
+}
+
+func (api API) joinLinkResponse(link pgstore.TripInviteLink) spec.JoinLink {
+	response := spec.JoinLink{
+		Code:      link.Code,
+		CreatedAt: link.CreatedAt.Time,
+		ID:        link.ID.String(),
+		URL:       api.publicBaseURL + "/join/" + link.Code,
+		Uses:      int(link.Uses),
+	}
+
+	if link.ExpiresAt.Valid {
+		expiresAt := link.ExpiresAt.Time
+		response.ExpiresAt = &expiresAt
+	}
+	if link.MaxUses.Valid {
+		maxUses := int(link.MaxUses.Int32)
+		response.MaxUses = &maxUses
+	}
+	if link.RevokedAt.Valid {
+		revokedAt := link.RevokedAt.Time
+		response.RevokedAt = &revokedAt
+	}
+
+	return response
+}
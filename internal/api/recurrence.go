@@ -0,0 +1,124 @@
+package api
+
+import (
+	"journey/internal/api/spec"
+	"journey/internal/pgstore"
+	"time"
+)
+
+// maxOccurrences bounds how many activities a recurrence expands to, enough
+// for a daily activity during a year long trip.
+const maxOccurrences = 366
+
+// weekdayNames are the weekday names accepted by recurrences, indexed by
+// time.Weekday.
+var weekdayNames = [...]string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// activityLocation is the time zone an activity described by body happens in,
+// so that its occurrences keep the same wall clock time across DST changes.
+func activityLocation(trip pgstore.Trip, body spec.CreateActivityRequest) *time.Location {
+	if body.Timezone == nil {
+		return trip.Location()
+	}
+
+	loc, err := time.LoadLocation(*body.Timezone)
+	if err != nil {
+		return trip.Location()
+	}
+	return loc
+}
+
+// occurrences returns when each occurrence of the recurring activity
+// described by body starts, the first one being body.OccursAt. They go on
+// until the recurrence until or the end of the trip, whichever comes first,
+// leaving out the ones which would end after the trip. At most
+// maxOccurrences+1 are returned, so that callers can tell there are too many.
+func occurrences(trip pgstore.Trip, body spec.CreateActivityRequest) []time.Time {
+	rule := body.Recurrence
+	if rule == nil {
+		return []time.Time{body.OccursAt}
+	}
+
+	interval := 1
+	if rule.Interval != nil {
+		interval = *rule.Interval
+	}
+
+	limit := trip.EndsAt.Time
+	if rule.Until != nil && rule.Until.Before(limit) {
+		limit = *rule.Until
+	}
+
+	var duration time.Duration
+	if endsAt := activityEnd(body); endsAt != nil {
+		duration = endsAt.Sub(body.OccursAt)
+	}
+
+	start := body.OccursAt.In(activityLocation(trip, body))
+
+	// add appends the occurrence offset days after start, at the same wall
+	// clock time, unless it's past the limit.
+	var out []time.Time
+	add := func(offset int) bool {
+		at := time.Date(
+			start.Year(), start.Month(), start.Day()+offset,
+			start.Hour(), start.Minute(), start.Second(), start.Nanosecond(),
+			start.Location(),
+		)
+		if at.After(limit) || at.Add(duration).After(trip.EndsAt.Time) {
+			return false
+		}
+		out = append(out, at)
+		return true
+	}
+
+	// The first occurrence is the activity itself, even when it's on a day
+	// the recurrence doesn't repeat on.
+	if !add(0) {
+		return out
+	}
+
+	switch rule.Frequency {
+	case pgstore.RecurrenceDaily:
+		for offset := interval; len(out) <= maxOccurrences; offset += interval {
+			if !add(offset) {
+				break
+			}
+		}
+	case pgstore.RecurrenceWeekly:
+		days := weekdaySet(rule.Weekdays, start.Weekday())
+		// Weeks start on monday, like in ISO 8601.
+		startOffset := (int(start.Weekday()) + 6) % 7
+		for week := 0; len(out) <= maxOccurrences; week += interval {
+			for day := 0; day < 7 && len(out) <= maxOccurrences; day++ {
+				offset := week*7 + day - startOffset
+				if offset <= 0 || !days[time.Weekday((day+1)%7)] {
+					continue
+				}
+				if !add(offset) {
+					return out
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+// weekdaySet is the set of days a weekly recurrence repeats on: the given
+// names or, when there are none, fallback.
+func weekdaySet(names []string, fallback time.Weekday) map[time.Weekday]bool {
+	days := make(map[time.Weekday]bool, len(names))
+	for _, name := range names {
+		for day, dayName := range weekdayNames {
+			if name == dayName {
+				days[time.Weekday(day)] = true
+			}
+		}
+	}
+
+	if len(days) == 0 {
+		days[fallback] = true
+	}
+	return days
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"journey/internal/api/spec"
+	"journey/internal/pgstore"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestOccurrences(t *testing.T) {
+	lisbon, err := time.LoadLocation("Europe/Lisbon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// at is the given day of March 2024 at the given hour in Lisbon, whose
+	// clocks moved forward an hour on March 31.
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, time.March, day, hour, 0, 0, 0, lisbon)
+	}
+	ptr := func(n int) *int { return &n }
+
+	// The trip goes from Monday March 25 to Friday April 12, in Lisbon.
+	trip := pgstore.Trip{
+		StartsAt: pgtype.Timestamptz{Valid: true, Time: at(25, 0)},
+		EndsAt:   pgtype.Timestamptz{Valid: true, Time: time.Date(2024, time.April, 12, 23, 0, 0, 0, lisbon)},
+		Timezone: "Europe/Lisbon",
+	}
+	april := func(day, hour int) time.Time {
+		return time.Date(2024, time.April, day, hour, 0, 0, 0, lisbon)
+	}
+
+	tests := []struct {
+		name string
+		body spec.CreateActivityRequest
+		want []time.Time
+	}{
+		{
+			name: "not recurring",
+			body: spec.CreateActivityRequest{OccursAt: at(26, 9)},
+			want: []time.Time{at(26, 9)},
+		},
+		{
+			name: "daily across the DST change",
+			body: spec.CreateActivityRequest{
+				OccursAt:   at(29, 9),
+				Recurrence: &spec.ActivityRecurrence{Frequency: pgstore.RecurrenceDaily, Until: ptrTime(april(2, 9))},
+			},
+			want: []time.Time{at(29, 9), at(30, 9), at(31, 9), april(1, 9), april(2, 9)},
+		},
+		{
+			name: "daily every other day",
+			body: spec.CreateActivityRequest{
+				OccursAt:   at(25, 20),
+				Recurrence: &spec.ActivityRecurrence{Frequency: pgstore.RecurrenceDaily, Interval: ptr(2), Until: ptrTime(at(31, 23))},
+			},
+			want: []time.Time{at(25, 20), at(27, 20), at(29, 20), at(31, 20)},
+		},
+		{
+			name: "weekly on the weekday of the first one",
+			body: spec.CreateActivityRequest{
+				OccursAt:   at(27, 18),
+				Recurrence: &spec.ActivityRecurrence{Frequency: pgstore.RecurrenceWeekly},
+			},
+			want: []time.Time{at(27, 18), april(3, 18), april(10, 18)},
+		},
+		{
+			name: "weekly on some days, starting on another one",
+			body: spec.CreateActivityRequest{
+				OccursAt: at(27, 8),
+				Recurrence: &spec.ActivityRecurrence{
+					Frequency: pgstore.RecurrenceWeekly,
+					Weekdays:  []string{"monday", "thursday", "sunday"},
+				},
+			},
+			want: []time.Time{
+				at(27, 8), at(28, 8), at(31, 8),
+				april(1, 8), april(4, 8), april(7, 8),
+				april(8, 8), april(11, 8),
+			},
+		},
+		{
+			name: "every other week",
+			body: spec.CreateActivityRequest{
+				OccursAt: at(26, 10),
+				Recurrence: &spec.ActivityRecurrence{
+					Frequency: pgstore.RecurrenceWeekly,
+					Interval:  ptr(2),
+					Weekdays:  []string{"monday", "tuesday", "friday"},
+				},
+			},
+			// The week of April 1 is skipped, the one of April 8 isn't.
+			want: []time.Time{at(26, 10), at(29, 10), april(8, 10), april(9, 10), april(12, 10)},
+		},
+		{
+			name: "occurrences ending after the trip are left out",
+			body: spec.CreateActivityRequest{
+				OccursAt:        april(10, 22),
+				DurationMinutes: ptr(90),
+				Recurrence:      &spec.ActivityRecurrence{Frequency: pgstore.RecurrenceDaily},
+			},
+			want: []time.Time{april(10, 22), april(11, 22)},
+		},
+		{
+			name: "in the activity time zone",
+			body: spec.CreateActivityRequest{
+				OccursAt:   time.Date(2024, time.March, 30, 9, 0, 0, 0, tokyo),
+				Timezone:   ptrString("Asia/Tokyo"),
+				Recurrence: &spec.ActivityRecurrence{Frequency: pgstore.RecurrenceDaily, Until: ptrTime(april(1, 12))},
+			},
+			// Tokyo has no DST, so in Lisbon they move an hour after
+			// March 31.
+			want: []time.Time{at(30, 0), at(31, 0), april(1, 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := occurrences(trip, tt.body)
+			if len(got) != len(tt.want) {
+				t.Fatalf("occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %v, want %v", i, got[i].In(lisbon), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOccurrencesAreBounded(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	trip := pgstore.Trip{
+		StartsAt: pgtype.Timestamptz{Valid: true, Time: start},
+		EndsAt:   pgtype.Timestamptz{Valid: true, Time: start.AddDate(3, 0, 0)},
+		Timezone: "UTC",
+	}
+
+	for _, rule := range []spec.ActivityRecurrence{
+		{Frequency: pgstore.RecurrenceDaily},
+		{Frequency: pgstore.RecurrenceWeekly, Weekdays: weekdayNames[:]},
+	} {
+		got := occurrences(trip, spec.CreateActivityRequest{OccursAt: start, Recurrence: &rule})
+		if len(got) != maxOccurrences+1 {
+			t.Errorf("%s recurrence has %d occurrences, want %d", rule.Frequency, len(got), maxOccurrences+1)
+		}
+	}
+}
+
+func ptrTime(t time.Time) *time.Time { return &t }
+
+func ptrString(s string) *string { return &s }
@@ -6,6 +6,7 @@ package spec
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
@@ -23,15 +24,133 @@ import (
 	"github.com/go-chi/render"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+	CookieAuthScopes = "cookieAuth.Scopes"
+)
+
+// Two activities of a trip whose times overlap, the earliest first.
+type ActivityConflict struct {
+	First  GetTripActivitiesResponseInnerArray `json:"first"`
+	Second GetTripActivitiesResponseInnerArray `json:"second"`
+}
+
+// The activity overlaps others of the trip
+type ActivityConflictResponse struct {
+	// Activities of the trip overlapping the given one.
+	Conflicts []GetTripActivitiesResponseInnerArray `json:"conflicts"`
+	Message   string                                `json:"message"`
+}
+
+// Estimated cost of an activity.
+type ActivityCost struct {
+	Amount float64 `json:"amount" validate:"min=0"`
+
+	// ISO 4217 currency code, e.g. BRL.
+	Currency string `json:"currency" validate:"required,iso4217"`
+}
+
+// Where an activity happens. Latitude and longitude go together.
+type ActivityLocation struct {
+	Latitude  *float64 `json:"latitude,omitempty" validate:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude,omitempty" validate:"omitempty,min=-180,max=180"`
+	Name      string   `json:"name" validate:"required,max=255"`
+}
+
+// How an activity repeats during the trip. The activity itself is the first occurrence.
+type ActivityRecurrence struct {
+	// One of daily or weekly.
+	Frequency string `json:"frequency" validate:"required,oneof=daily weekly"`
+
+	// Repeat every interval days or weeks, 1 by default.
+	Interval *int `json:"interval,omitempty" validate:"omitempty,min=1"`
+
+	// Last moment to repeat at, the trip ends_at by default.
+	Until *time.Time `json:"until,omitempty"`
+
+	// Days of the week to repeat on, e.g. monday, for the weekly frequency. The weekday of occurs_at by default.
+	Weekdays []string `json:"weekdays,omitempty" validate:"omitempty,max=7,dive,oneof=monday tuesday wednesday thursday friday saturday sunday"`
+}
+
+// BulkInviteParticipant defines model for BulkInviteParticipant.
+type BulkInviteParticipant struct {
+	Email openapi_types.Email `json:"email" validate:"required,email"`
+	Name  *string             `json:"name,omitempty" validate:"omitempty,max=255"`
+}
+
+// BulkInviteRequest defines model for BulkInviteRequest.
+type BulkInviteRequest struct {
+	Participants []BulkInviteParticipant `json:"participants" validate:"required,min=1,max=500,dive"`
+}
+
+// A secret URL calendar apps can subscribe to. Anyone who knows it can read the trip calendar.
+type CalendarFeed struct {
+	CreatedAt time.Time `json:"created_at"`
+	URL       string    `json:"url"`
+}
+
+// ConfirmParticipantRequest defines model for ConfirmParticipantRequest.
+type ConfirmParticipantRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// ConfirmTokenResponse defines model for ConfirmTokenResponse.
+type ConfirmTokenResponse struct {
+	ParticipantID *string `json:"participantId,omitempty"`
+
+	// Status of the participant after the confirmation, confirmed or waitlisted when the trip is full.
+	Status *string `json:"status,omitempty"`
+	TripID string  `json:"tripId"`
+}
+
 // CreateActivityRequest defines model for CreateActivityRequest.
 type CreateActivityRequest struct {
-	OccursAt time.Time `json:"occurs_at" validate:"required"`
-	Title    string    `json:"title" validate:"required"`
+	// One of food, transport, lodging, sightseeing or other. other by default.
+	Category *string `json:"category,omitempty" validate:"omitempty,oneof=food transport lodging sightseeing other"`
+
+	// Estimated cost of an activity.
+	Cost *ActivityCost `json:"cost,omitempty"`
+
+	// How long the activity lasts, as an alternative to ends_at.
+	DurationMinutes *int `json:"duration_minutes,omitempty" validate:"omitempty,min=1"`
+
+	// When the activity ends. Can't be given together with duration_minutes.
+	EndsAt *time.Time `json:"ends_at,omitempty"`
+
+	// Where an activity happens. Latitude and longitude go together.
+	Location *ActivityLocation `json:"location,omitempty"`
+	Notes    *string           `json:"notes,omitempty" validate:"omitempty,max=2000"`
+	OccursAt time.Time         `json:"occurs_at" validate:"required"`
+
+	// How an activity repeats during the trip. The activity itself is the first occurrence.
+	Recurrence *ActivityRecurrence `json:"recurrence,omitempty"`
+
+	// IANA time zone name, e.g. America/Sao_Paulo.
+	Timezone *string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	Title    string  `json:"title" validate:"required"`
 }
 
 // CreateActivityResponse defines model for CreateActivityResponse.
 type CreateActivityResponse struct {
 	ActivityID string `json:"activityId"`
+
+	// Activities of the trip overlapping the new one.
+	Conflicts []GetTripActivitiesResponseInnerArray `json:"conflicts"`
+
+	// IDs of every occurrence when the activity repeats, activityId being the first one.
+	OccurrenceIDs []string `json:"occurrenceIds,omitempty"`
+
+	// Set when the activity repeats.
+	SeriesID *string `json:"seriesId,omitempty"`
+}
+
+// CreateJoinLinkRequest defines model for CreateJoinLinkRequest.
+type CreateJoinLinkRequest struct {
+	// The link stops working after this moment. Never expires when omitted.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// How many people can join through the link. Unlimited when omitted.
+	MaxUses *int `json:"max_uses,omitempty" validate:"omitempty,min=1"`
 }
 
 // CreateLinkRequest defines model for CreateLinkRequest.
@@ -50,9 +169,18 @@ type CreateTripRequest struct {
 	Destination    string                `json:"destination" validate:"required,min=4"`
 	EmailsToInvite []openapi_types.Email `json:"emails_to_invite" validate:"required,dive,email"`
 	EndsAt         time.Time             `json:"ends_at" validate:"required"`
-	OwnerEmail     openapi_types.Email   `json:"owner_email" validate:"required,email"`
-	OwnerName      string                `json:"owner_name" validate:"required"`
-	StartsAt       time.Time             `json:"starts_at" validate:"required"`
+
+	// Locale of the e-mails sent for the trip, pt-BR by default.
+	Locale *string `json:"locale,omitempty" validate:"omitempty,oneof=pt-BR en-US"`
+
+	// Seats for participants, not counting the owner. Unlimited when omitted.
+	MaxParticipants *int                `json:"max_participants,omitempty" validate:"omitempty,min=1"`
+	OwnerEmail      openapi_types.Email `json:"owner_email" validate:"required,email"`
+	OwnerName       string              `json:"owner_name" validate:"required"`
+	StartsAt        time.Time           `json:"starts_at" validate:"required"`
+
+	// IANA time zone name, e.g. America/Sao_Paulo.
+	Timezone *string `json:"timezone,omitempty" validate:"omitempty,timezone"`
 }
 
 // CreateTripResponse defines model for CreateTripResponse.
@@ -60,11 +188,56 @@ type CreateTripResponse struct {
 	TripID string `json:"tripId"`
 }
 
+// EmailOutboxItem defines model for EmailOutboxItem.
+type EmailOutboxItem struct {
+	Attempts      int                  `json:"attempts"`
+	CreatedAt     time.Time            `json:"created_at"`
+	ID            string               `json:"id"`
+	Kind          string               `json:"kind"`
+	LastError     *string              `json:"last_error"`
+	NextAttemptAt time.Time            `json:"next_attempt_at"`
+	ParticipantID *string              `json:"participant_id"`
+	Recipient     *openapi_types.Email `json:"recipient"`
+	SentAt        *time.Time           `json:"sent_at"`
+	Status        string               `json:"status"`
+	TripID        *string              `json:"trip_id"`
+}
+
 // Bad request
 type Error struct {
+	Details []ErrorDetail `json:"details,omitempty"`
+	Message string        `json:"message"`
+}
+
+// ErrorDetail defines model for ErrorDetail.
+type ErrorDetail struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
+// GetActivityConflictsResponse defines model for GetActivityConflictsResponse.
+type GetActivityConflictsResponse struct {
+	Conflicts []ActivityConflict `json:"conflicts"`
+}
+
+// GetEmailOutboxResponse defines model for GetEmailOutboxResponse.
+type GetEmailOutboxResponse struct {
+	Emails []EmailOutboxItem `json:"emails"`
+}
+
+// GetJoinLinkResponse defines model for GetJoinLinkResponse.
+type GetJoinLinkResponse struct {
+	Destination string    `json:"destination"`
+	EndsAt      time.Time `json:"ends_at"`
+	StartsAt    time.Time `json:"starts_at"`
+	TripID      string    `json:"trip_id"`
+}
+
+// GetJoinLinksResponse defines model for GetJoinLinksResponse.
+type GetJoinLinksResponse struct {
+	Links []JoinLink `json:"links"`
+}
+
 // GetLinksResponse defines model for GetLinksResponse.
 type GetLinksResponse struct {
 	Links []GetLinksResponseArray `json:"links"`
@@ -84,9 +257,23 @@ type GetTripActivitiesResponse struct {
 
 // GetTripActivitiesResponseInnerArray defines model for GetTripActivitiesResponseInnerArray.
 type GetTripActivitiesResponseInnerArray struct {
-	ID       string    `json:"id"`
-	OccursAt time.Time `json:"occurs_at"`
-	Title    string    `json:"title"`
+	// One of food, transport, lodging, sightseeing or other.
+	Category string `json:"category"`
+
+	// Estimated cost of an activity.
+	Cost   *ActivityCost `json:"cost,omitempty"`
+	EndsAt *time.Time    `json:"ends_at"`
+	ID     string        `json:"id"`
+
+	// Where an activity happens. Latitude and longitude go together.
+	Location *ActivityLocation `json:"location,omitempty"`
+	Notes    *string           `json:"notes"`
+	OccursAt time.Time         `json:"occurs_at"`
+
+	// Set when the activity is an occurrence of a recurring one.
+	SeriesID *string `json:"series_id,omitempty"`
+	Timezone string  `json:"timezone"`
+	Title    string  `json:"title"`
 }
 
 // GetTripActivitiesResponseOuterArray defines model for GetTripActivitiesResponseOuterArray.
@@ -106,7 +293,12 @@ type GetTripDetailsResponseTripObj struct {
 	EndsAt      time.Time `json:"ends_at"`
 	ID          string    `json:"id"`
 	IsConfirmed bool      `json:"is_confirmed"`
-	StartsAt    time.Time `json:"starts_at"`
+	Locale      string    `json:"locale"`
+
+	// Seats for participants, not counting the owner. Null when unlimited.
+	MaxParticipants *int      `json:"max_participants"`
+	StartsAt        time.Time `json:"starts_at"`
+	Timezone        string    `json:"timezone"`
 }
 
 // GetTripParticipantsResponse defines model for GetTripParticipantsResponse.
@@ -116,10 +308,50 @@ type GetTripParticipantsResponse struct {
 
 // GetTripParticipantsResponseArray defines model for GetTripParticipantsResponseArray.
 type GetTripParticipantsResponseArray struct {
+	ConfirmedAt *time.Time          `json:"confirmed_at"`
 	Email       openapi_types.Email `json:"email"`
 	ID          string              `json:"id"`
-	IsConfirmed bool                `json:"is_confirmed"`
-	Name        *string             `json:"name"`
+
+	// How many invitation e-mails were sent.
+	InviteCount int `json:"invite_count"`
+
+	// When the first invitation e-mail was sent.
+	InvitedAt     *time.Time `json:"invited_at"`
+	IsConfirmed   bool       `json:"is_confirmed"`
+	LastInvitedAt *time.Time `json:"last_invited_at"`
+	Name          *string    `json:"name"`
+
+	// One of co-organizer, member or viewer.
+	Role string `json:"role"`
+
+	// One of invited, confirmed, waitlisted, declined or removed.
+	Status string `json:"status"`
+
+	// When the participant joined the waitlist. Waitlisted participants are promoted in this order.
+	WaitlistedAt *time.Time `json:"waitlisted_at"`
+}
+
+// ImportActivitiesResponse defines model for ImportActivitiesResponse.
+type ImportActivitiesResponse struct {
+	// Activities created from the calendar events, or which would be in a dry run.
+	Activities []ImportedActivity     `json:"activities"`
+	DryRun     bool                   `json:"dry_run"`
+	Skipped    []SkippedCalendarEvent `json:"skipped"`
+}
+
+// An activity created from a calendar event.
+type ImportedActivity struct {
+	EndsAt *time.Time `json:"ends_at"`
+
+	// null in a dry run.
+	ID       *string   `json:"id"`
+	Notes    *string   `json:"notes"`
+	OccursAt time.Time `json:"occurs_at"`
+	Timezone string    `json:"timezone"`
+	Title    string    `json:"title"`
+
+	// UID of the calendar event.
+	UID string `json:"uid"`
 }
 
 // InviteParticipantRequest defines model for InviteParticipantRequest.
@@ -127,11 +359,167 @@ type InviteParticipantRequest struct {
 	Email openapi_types.Email `json:"email" validate:"required,email"`
 }
 
+// InviteParticipantResult defines model for InviteParticipantResult.
+type InviteParticipantResult struct {
+	Email openapi_types.Email `json:"email"`
+
+	// One of created, already_invited or owner.
+	Outcome string `json:"outcome"`
+
+	// The new or existing participant, null when the e-mail is the owner's.
+	ParticipantID *string `json:"participant_id"`
+}
+
+// InviteParticipantsResponse defines model for InviteParticipantsResponse.
+type InviteParticipantsResponse struct {
+	Results []InviteParticipantResult `json:"results"`
+}
+
+// JoinLink defines model for JoinLink.
+type JoinLink struct {
+	Code      string     `json:"code"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	ID        string     `json:"id"`
+	MaxUses   *int       `json:"max_uses"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	URL       string     `json:"url"`
+	Uses      int        `json:"uses"`
+}
+
+// JoinTripRequest defines model for JoinTripRequest.
+type JoinTripRequest struct {
+	Email openapi_types.Email `json:"email" validate:"required,email"`
+	Name  string              `json:"name" validate:"required,max=255"`
+}
+
+// JoinTripResponse defines model for JoinTripResponse.
+type JoinTripResponse struct {
+	// created, or already_invited when the e-mail was already on the trip.
+	Outcome       string `json:"outcome"`
+	ParticipantID string `json:"participant_id"`
+	TripID        string `json:"trip_id"`
+}
+
+// MagicLinkRequest defines model for MagicLinkRequest.
+type MagicLinkRequest struct {
+	Email openapi_types.Email `json:"email" validate:"required,email"`
+}
+
+// SessionResponse defines model for SessionResponse.
+type SessionResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Session token, also set in the journey_session cookie. Send it as a bearer token when cookies aren't an option.
+	Token string `json:"token"`
+}
+
+// A calendar event which isn't imported.
+type SkippedCalendarEvent struct {
+	Reason  string `json:"reason"`
+	Summary string `json:"summary"`
+
+	// UID of the calendar event.
+	UID string `json:"uid"`
+}
+
+// TransferOwnershipRequest defines model for TransferOwnershipRequest.
+type TransferOwnershipRequest struct {
+	// Confirmed participant that becomes the new owner. The current owner becomes a co-organizer.
+	ParticipantID string `json:"participant_id" validate:"required,uuid"`
+}
+
+// UpdateActivityRequest defines model for UpdateActivityRequest.
+type UpdateActivityRequest struct {
+	// One of food, transport, lodging, sightseeing or other. other by default.
+	Category *string `json:"category,omitempty" validate:"omitempty,oneof=food transport lodging sightseeing other"`
+
+	// Estimated cost of an activity.
+	Cost *ActivityCost `json:"cost,omitempty"`
+
+	// How long the activity lasts, as an alternative to ends_at.
+	DurationMinutes *int `json:"duration_minutes,omitempty" validate:"omitempty,min=1"`
+
+	// When the activity ends. Can't be given together with duration_minutes.
+	EndsAt *time.Time `json:"ends_at,omitempty"`
+
+	// Where an activity happens. Latitude and longitude go together.
+	Location *ActivityLocation `json:"location,omitempty"`
+	Notes    *string           `json:"notes,omitempty" validate:"omitempty,max=2000"`
+	OccursAt time.Time         `json:"occurs_at" validate:"required"`
+
+	// IANA time zone name, e.g. America/Sao_Paulo.
+	Timezone *string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	Title    string  `json:"title" validate:"required"`
+}
+
+// UpdateActivityResponse defines model for UpdateActivityResponse.
+type UpdateActivityResponse struct {
+	// Activities of the trip overlapping the updated one.
+	Conflicts []GetTripActivitiesResponseInnerArray `json:"conflicts"`
+}
+
+// UpdateParticipantRoleRequest defines model for UpdateParticipantRoleRequest.
+type UpdateParticipantRoleRequest struct {
+	// One of co-organizer, member or viewer.
+	Role string `json:"role" validate:"required,oneof=co-organizer member viewer"`
+}
+
+// UpdateTripConflictResponse defines model for UpdateTripConflictResponse.
+type UpdateTripConflictResponse struct {
+	Activities []GetTripActivitiesResponseInnerArray `json:"activities"`
+	Message    string                                `json:"message"`
+}
+
 // UpdateTripRequest defines model for UpdateTripRequest.
 type UpdateTripRequest struct {
 	Destination string    `json:"destination" validate:"required,min=4"`
 	EndsAt      time.Time `json:"ends_at" validate:"required"`
-	StartsAt    time.Time `json:"starts_at" validate:"required"`
+
+	// Seats for participants, not counting the owner. The current limit is kept when omitted, and 0 removes it.
+	MaxParticipants *int `json:"max_participants,omitempty" validate:"omitempty,min=0"`
+
+	// Moves every trip activity by the same delta as starts_at.
+	ShiftActivities *bool     `json:"shift_activities,omitempty"`
+	StartsAt        time.Time `json:"starts_at" validate:"required"`
+
+	// IANA time zone name, e.g. America/Sao_Paulo.
+	Timezone *string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+}
+
+// GetAdminEmailsParams defines parameters for GetAdminEmails.
+type GetAdminEmailsParams struct {
+	Status *string `json:"status,omitempty"`
+}
+
+// PostAuthMagicLinkJSONBody defines parameters for PostAuthMagicLink.
+type PostAuthMagicLinkJSONBody MagicLinkRequest
+
+// GetAuthSessionParams defines parameters for GetAuthSession.
+type GetAuthSessionParams struct {
+	Token string `json:"token"`
+}
+
+// GetConfirmParams defines parameters for GetConfirm.
+type GetConfirmParams struct {
+	Token string `json:"token"`
+}
+
+// PostJoinCodeJSONBody defines parameters for PostJoinCode.
+type PostJoinCodeJSONBody JoinTripRequest
+
+// PatchParticipantsParticipantIDConfirmParams defines parameters for PatchParticipantsParticipantIDConfirm.
+type PatchParticipantsParticipantIDConfirmParams struct {
+	Token string `json:"token"`
+}
+
+// PatchParticipantsParticipantIDConfirmJSONBody defines parameters for PatchParticipantsParticipantIDConfirm.
+type PatchParticipantsParticipantIDConfirmJSONBody ConfirmParticipantRequest
+
+// PatchParticipantsParticipantIDDeclineParams defines parameters for PatchParticipantsParticipantIDDecline.
+type PatchParticipantsParticipantIDDeclineParams struct {
+	// The invitation token. Without it the caller must be signed in with the invited e-mail.
+	Token *string `json:"token,omitempty"`
 }
 
 // PostTripsJSONBody defines parameters for PostTrips.
@@ -140,15 +528,98 @@ type PostTripsJSONBody CreateTripRequest
 // PutTripsTripIDJSONBody defines parameters for PutTripsTripID.
 type PutTripsTripIDJSONBody UpdateTripRequest
 
+// GetTripsTripIDActivitiesParams defines parameters for GetTripsTripIDActivities.
+type GetTripsTripIDActivitiesParams struct {
+	// Only return the activities of this category: food, transport, lodging, sightseeing or other.
+	Category *string `json:"category,omitempty"`
+}
+
+// PostTripsTripIDActivitiesParams defines parameters for PostTripsTripIDActivities.
+type PostTripsTripIDActivitiesParams struct {
+	// Reject the activity with a 409 when it overlaps others of the trip, instead of only listing them.
+	Strict *bool `json:"strict,omitempty"`
+}
+
 // PostTripsTripIDActivitiesJSONBody defines parameters for PostTripsTripIDActivities.
 type PostTripsTripIDActivitiesJSONBody CreateActivityRequest
 
+// PostTripsTripIDActivitiesImportParams defines parameters for PostTripsTripIDActivitiesImport.
+type PostTripsTripIDActivitiesImportParams struct {
+	// Only preview the activities which would be imported, without creating them.
+	DryRun *bool `json:"dry_run,omitempty"`
+}
+
+// DeleteTripsTripIDActivitiesActivityIDParams defines parameters for DeleteTripsTripIDActivitiesActivityID.
+type DeleteTripsTripIDActivitiesActivityIDParams struct {
+	// One of occurrence or series, occurrence by default. With series, every occurrence of the activity series is changed.
+	Scope *string `json:"scope,omitempty"`
+}
+
+// PutTripsTripIDActivitiesActivityIDParams defines parameters for PutTripsTripIDActivitiesActivityID.
+type PutTripsTripIDActivitiesActivityIDParams struct {
+	// Reject the activity with a 409 when it overlaps others of the trip, instead of only listing them.
+	Strict *bool `json:"strict,omitempty"`
+
+	// One of occurrence or series, occurrence by default. With series, every occurrence of the activity series is changed.
+	Scope *string `json:"scope,omitempty"`
+}
+
+// PutTripsTripIDActivitiesActivityIDJSONBody defines parameters for PutTripsTripIDActivitiesActivityID.
+type PutTripsTripIDActivitiesActivityIDJSONBody UpdateActivityRequest
+
+// GetTripsTripIDConfirmParams defines parameters for GetTripsTripIDConfirm.
+type GetTripsTripIDConfirmParams struct {
+	Token string `json:"token"`
+}
+
 // PostTripsTripIDInvitesJSONBody defines parameters for PostTripsTripIDInvites.
 type PostTripsTripIDInvitesJSONBody InviteParticipantRequest
 
+// PostTripsTripIDInvitesBulkJSONBody defines parameters for PostTripsTripIDInvitesBulk.
+type PostTripsTripIDInvitesBulkJSONBody BulkInviteRequest
+
+// PostTripsTripIDJoinLinksJSONBody defines parameters for PostTripsTripIDJoinLinks.
+type PostTripsTripIDJoinLinksJSONBody CreateJoinLinkRequest
+
 // PostTripsTripIDLinksJSONBody defines parameters for PostTripsTripIDLinks.
 type PostTripsTripIDLinksJSONBody CreateLinkRequest
 
+// PostTripsTripIDOwnerJSONBody defines parameters for PostTripsTripIDOwner.
+type PostTripsTripIDOwnerJSONBody TransferOwnershipRequest
+
+// GetTripsTripIDParticipantsParams defines parameters for GetTripsTripIDParticipants.
+type GetTripsTripIDParticipantsParams struct {
+	// Only return the participants with this status: invited, confirmed, waitlisted, declined or removed.
+	Status *string `json:"status,omitempty"`
+}
+
+// PatchTripsTripIDParticipantsParticipantIDRoleJSONBody defines parameters for PatchTripsTripIDParticipantsParticipantIDRole.
+type PatchTripsTripIDParticipantsParticipantIDRoleJSONBody UpdateParticipantRoleRequest
+
+// PostAuthMagicLinkJSONRequestBody defines body for PostAuthMagicLink for application/json ContentType.
+type PostAuthMagicLinkJSONRequestBody PostAuthMagicLinkJSONBody
+
+// Bind implements render.Binder.
+func (PostAuthMagicLinkJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostJoinCodeJSONRequestBody defines body for PostJoinCode for application/json ContentType.
+type PostJoinCodeJSONRequestBody PostJoinCodeJSONBody
+
+// Bind implements render.Binder.
+func (PostJoinCodeJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PatchParticipantsParticipantIDConfirmJSONRequestBody defines body for PatchParticipantsParticipantIDConfirm for application/json ContentType.
+type PatchParticipantsParticipantIDConfirmJSONRequestBody PatchParticipantsParticipantIDConfirmJSONBody
+
+// Bind implements render.Binder.
+func (PatchParticipantsParticipantIDConfirmJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
 // PostTripsJSONRequestBody defines body for PostTrips for application/json ContentType.
 type PostTripsJSONRequestBody PostTripsJSONBody
 
@@ -173,6 +644,14 @@ func (PostTripsTripIDActivitiesJSONRequestBody) Bind(*http.Request) error {
 	return nil
 }
 
+// PutTripsTripIDActivitiesActivityIDJSONRequestBody defines body for PutTripsTripIDActivitiesActivityID for application/json ContentType.
+type PutTripsTripIDActivitiesActivityIDJSONRequestBody PutTripsTripIDActivitiesActivityIDJSONBody
+
+// Bind implements render.Binder.
+func (PutTripsTripIDActivitiesActivityIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
 // PostTripsTripIDInvitesJSONRequestBody defines body for PostTripsTripIDInvites for application/json ContentType.
 type PostTripsTripIDInvitesJSONRequestBody PostTripsTripIDInvitesJSONBody
 
@@ -181,6 +660,22 @@ func (PostTripsTripIDInvitesJSONRequestBody) Bind(*http.Request) error {
 	return nil
 }
 
+// PostTripsTripIDInvitesBulkJSONRequestBody defines body for PostTripsTripIDInvitesBulk for application/json ContentType.
+type PostTripsTripIDInvitesBulkJSONRequestBody PostTripsTripIDInvitesBulkJSONBody
+
+// Bind implements render.Binder.
+func (PostTripsTripIDInvitesBulkJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostTripsTripIDJoinLinksJSONRequestBody defines body for PostTripsTripIDJoinLinks for application/json ContentType.
+type PostTripsTripIDJoinLinksJSONRequestBody PostTripsTripIDJoinLinksJSONBody
+
+// Bind implements render.Binder.
+func (PostTripsTripIDJoinLinksJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
 // PostTripsTripIDLinksJSONRequestBody defines body for PostTripsTripIDLinks for application/json ContentType.
 type PostTripsTripIDLinksJSONRequestBody PostTripsTripIDLinksJSONBody
 
@@ -189,6 +684,22 @@ func (PostTripsTripIDLinksJSONRequestBody) Bind(*http.Request) error {
 	return nil
 }
 
+// PostTripsTripIDOwnerJSONRequestBody defines body for PostTripsTripIDOwner for application/json ContentType.
+type PostTripsTripIDOwnerJSONRequestBody PostTripsTripIDOwnerJSONBody
+
+// Bind implements render.Binder.
+func (PostTripsTripIDOwnerJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PatchTripsTripIDParticipantsParticipantIDRoleJSONRequestBody defines body for PatchTripsTripIDParticipantsParticipantIDRole for application/json ContentType.
+type PatchTripsTripIDParticipantsParticipantIDRoleJSONRequestBody PatchTripsTripIDParticipantsParticipantIDRoleJSONBody
+
+// Bind implements render.Binder.
+func (PatchTripsTripIDParticipantsParticipantIDRoleJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
 // Response is a common response struct for all the API calls.
 // A Response object may be instantiated via functions for specific operation responses.
 // It may also be instantiated directly, for the purpose of responding with a single status code.
@@ -230,19 +741,19 @@ func (resp *Response) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	return e.Encode(resp.body)
 }
 
-// PatchParticipantsParticipantIDConfirmJSON204Response is a constructor method for a PatchParticipantsParticipantIDConfirm response.
+// GetAdminEmailsJSON200Response is a constructor method for a GetAdminEmails response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PatchParticipantsParticipantIDConfirmJSON204Response(body interface{}) *Response {
+func GetAdminEmailsJSON200Response(body GetEmailOutboxResponse) *Response {
 	return &Response{
 		body:        body,
-		Code:        204,
+		Code:        200,
 		contentType: "application/json",
 	}
 }
 
-// PatchParticipantsParticipantIDConfirmJSON400Response is a constructor method for a PatchParticipantsParticipantIDConfirm response.
+// GetAdminEmailsJSON400Response is a constructor method for a GetAdminEmails response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PatchParticipantsParticipantIDConfirmJSON400Response(body Error) *Response {
+func GetAdminEmailsJSON400Response(body Error) *Response {
 	return &Response{
 		body:        body,
 		Code:        400,
@@ -250,39 +761,39 @@ func PatchParticipantsParticipantIDConfirmJSON400Response(body Error) *Response
 	}
 }
 
-// PostTripsJSON201Response is a constructor method for a PostTrips response.
+// GetAdminEmailsJSON401Response is a constructor method for a GetAdminEmails response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsJSON201Response(body CreateTripResponse) *Response {
+func GetAdminEmailsJSON401Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        201,
+		Code:        401,
 		contentType: "application/json",
 	}
 }
 
-// PostTripsJSON400Response is a constructor method for a PostTrips response.
+// GetAdminEmailsJSON403Response is a constructor method for a GetAdminEmails response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsJSON400Response(body Error) *Response {
+func GetAdminEmailsJSON403Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        403,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDJSON200Response is a constructor method for a GetTripsTripID response.
+// PostAdminEmailsEmailIDRetryJSON204Response is a constructor method for a PostAdminEmailsEmailIDRetry response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDJSON200Response(body GetTripDetailsResponse) *Response {
+func PostAdminEmailsEmailIDRetryJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        200,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDJSON400Response is a constructor method for a GetTripsTripID response.
+// PostAdminEmailsEmailIDRetryJSON400Response is a constructor method for a PostAdminEmailsEmailIDRetry response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDJSON400Response(body Error) *Response {
+func PostAdminEmailsEmailIDRetryJSON400Response(body Error) *Response {
 	return &Response{
 		body:        body,
 		Code:        400,
@@ -290,79 +801,79 @@ func GetTripsTripIDJSON400Response(body Error) *Response {
 	}
 }
 
-// PutTripsTripIDJSON204Response is a constructor method for a PutTripsTripID response.
+// PostAdminEmailsEmailIDRetryJSON401Response is a constructor method for a PostAdminEmailsEmailIDRetry response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PutTripsTripIDJSON204Response(body interface{}) *Response {
+func PostAdminEmailsEmailIDRetryJSON401Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        204,
+		Code:        401,
 		contentType: "application/json",
 	}
 }
 
-// PutTripsTripIDJSON400Response is a constructor method for a PutTripsTripID response.
+// PostAdminEmailsEmailIDRetryJSON403Response is a constructor method for a PostAdminEmailsEmailIDRetry response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PutTripsTripIDJSON400Response(body Error) *Response {
+func PostAdminEmailsEmailIDRetryJSON403Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        403,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDActivitiesJSON200Response is a constructor method for a GetTripsTripIDActivities response.
+// PostAdminEmailsEmailIDRetryJSON404Response is a constructor method for a PostAdminEmailsEmailIDRetry response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDActivitiesJSON200Response(body GetTripActivitiesResponse) *Response {
+func PostAdminEmailsEmailIDRetryJSON404Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        200,
+		Code:        404,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDActivitiesJSON400Response is a constructor method for a GetTripsTripIDActivities response.
+// PostAuthMagicLinkJSON204Response is a constructor method for a PostAuthMagicLink response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDActivitiesJSON400Response(body Error) *Response {
+func PostAuthMagicLinkJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
 
-// PostTripsTripIDActivitiesJSON201Response is a constructor method for a PostTripsTripIDActivities response.
+// PostAuthMagicLinkJSON400Response is a constructor method for a PostAuthMagicLink response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDActivitiesJSON201Response(body CreateActivityResponse) *Response {
+func PostAuthMagicLinkJSON400Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        201,
+		Code:        400,
 		contentType: "application/json",
 	}
 }
 
-// PostTripsTripIDActivitiesJSON400Response is a constructor method for a PostTripsTripIDActivities response.
+// PostAuthMagicLinkJSON429Response is a constructor method for a PostAuthMagicLink response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDActivitiesJSON400Response(body Error) *Response {
+func PostAuthMagicLinkJSON429Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        429,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDConfirmJSON204Response is a constructor method for a GetTripsTripIDConfirm response.
+// GetAuthSessionJSON200Response is a constructor method for a GetAuthSession response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDConfirmJSON204Response(body interface{}) *Response {
+func GetAuthSessionJSON200Response(body SessionResponse) *Response {
 	return &Response{
 		body:        body,
-		Code:        204,
+		Code:        200,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDConfirmJSON400Response is a constructor method for a GetTripsTripIDConfirm response.
+// GetAuthSessionJSON400Response is a constructor method for a GetAuthSession response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDConfirmJSON400Response(body Error) *Response {
+func GetAuthSessionJSON400Response(body Error) *Response {
 	return &Response{
 		body:        body,
 		Code:        400,
@@ -370,29 +881,29 @@ func GetTripsTripIDConfirmJSON400Response(body Error) *Response {
 	}
 }
 
-// PostTripsTripIDInvitesJSON201Response is a constructor method for a PostTripsTripIDInvites response.
+// GetCalendarTokenIcsJSON400Response is a constructor method for a GetCalendarTokenIcs response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDInvitesJSON201Response(body interface{}) *Response {
+func GetCalendarTokenIcsJSON400Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        201,
+		Code:        400,
 		contentType: "application/json",
 	}
 }
 
-// PostTripsTripIDInvitesJSON400Response is a constructor method for a PostTripsTripIDInvites response.
+// GetCalendarTokenIcsJSON404Response is a constructor method for a GetCalendarTokenIcs response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDInvitesJSON400Response(body Error) *Response {
+func GetCalendarTokenIcsJSON404Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        404,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDLinksJSON200Response is a constructor method for a GetTripsTripIDLinks response.
+// GetJoinCodeJSON200Response is a constructor method for a GetJoinCode response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDLinksJSON200Response(body GetLinksResponse) *Response {
+func GetJoinCodeJSON200Response(body GetJoinLinkResponse) *Response {
 	return &Response{
 		body:        body,
 		Code:        200,
@@ -400,9 +911,9 @@ func GetTripsTripIDLinksJSON200Response(body GetLinksResponse) *Response {
 	}
 }
 
-// GetTripsTripIDLinksJSON400Response is a constructor method for a GetTripsTripIDLinks response.
+// GetJoinCodeJSON400Response is a constructor method for a GetJoinCode response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDLinksJSON400Response(body Error) *Response {
+func GetJoinCodeJSON400Response(body Error) *Response {
 	return &Response{
 		body:        body,
 		Code:        400,
@@ -410,39 +921,339 @@ func GetTripsTripIDLinksJSON400Response(body Error) *Response {
 	}
 }
 
-// PostTripsTripIDLinksJSON201Response is a constructor method for a PostTripsTripIDLinks response.
+// GetJoinCodeJSON404Response is a constructor method for a GetJoinCode response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDLinksJSON201Response(body CreateLinkResponse) *Response {
+func GetJoinCodeJSON404Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        201,
+		Code:        404,
 		contentType: "application/json",
 	}
 }
 
-// PostTripsTripIDLinksJSON400Response is a constructor method for a PostTripsTripIDLinks response.
+// PostJoinCodeJSON200Response is a constructor method for a PostJoinCode response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDLinksJSON400Response(body Error) *Response {
+func PostJoinCodeJSON200Response(body JoinTripResponse) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        200,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDParticipantsJSON200Response is a constructor method for a GetTripsTripIDParticipants response.
+// PostJoinCodeJSON400Response is a constructor method for a PostJoinCode response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDParticipantsJSON200Response(body GetTripParticipantsResponse) *Response {
+func PostJoinCodeJSON400Response(body Error) *Response {
 	return &Response{
 		body:        body,
-		Code:        200,
+		Code:        400,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDParticipantsJSON400Response is a constructor method for a GetTripsTripIDParticipants response.
+// PostJoinCodeJSON404Response is a constructor method for a PostJoinCode response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDParticipantsJSON400Response(body Error) *Response {
+func PostJoinCodeJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PatchParticipantsParticipantIDConfirmJSON200Response is a constructor method for a PatchParticipantsParticipantIDConfirm response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchParticipantsParticipantIDConfirmJSON200Response(body ConfirmTokenResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PatchParticipantsParticipantIDConfirmJSON400Response is a constructor method for a PatchParticipantsParticipantIDConfirm response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchParticipantsParticipantIDConfirmJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PatchParticipantsParticipantIDConfirmJSON404Response is a constructor method for a PatchParticipantsParticipantIDConfirm response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchParticipantsParticipantIDConfirmJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PatchParticipantsParticipantIDDeclineJSON204Response is a constructor method for a PatchParticipantsParticipantIDDecline response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchParticipantsParticipantIDDeclineJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PatchParticipantsParticipantIDDeclineJSON400Response is a constructor method for a PatchParticipantsParticipantIDDecline response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchParticipantsParticipantIDDeclineJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PatchParticipantsParticipantIDDeclineJSON401Response is a constructor method for a PatchParticipantsParticipantIDDecline response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchParticipantsParticipantIDDeclineJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PatchParticipantsParticipantIDDeclineJSON403Response is a constructor method for a PatchParticipantsParticipantIDDecline response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchParticipantsParticipantIDDeclineJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PatchParticipantsParticipantIDDeclineJSON404Response is a constructor method for a PatchParticipantsParticipantIDDecline response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchParticipantsParticipantIDDeclineJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsJSON201Response is a constructor method for a PostTrips response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsJSON201Response(body CreateTripResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsJSON400Response is a constructor method for a PostTrips response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDJSON200Response is a constructor method for a GetTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDJSON200Response(body GetTripDetailsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDJSON400Response is a constructor method for a GetTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDJSON401Response is a constructor method for a GetTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDJSON403Response is a constructor method for a GetTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDJSON204Response is a constructor method for a PutTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDJSON400Response is a constructor method for a PutTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDJSON401Response is a constructor method for a PutTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDJSON403Response is a constructor method for a PutTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDJSON409Response is a constructor method for a PutTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDJSON409Response(body UpdateTripConflictResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDActivitiesJSON200Response is a constructor method for a GetTripsTripIDActivities response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDActivitiesJSON200Response(body GetTripActivitiesResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDActivitiesJSON400Response is a constructor method for a GetTripsTripIDActivities response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDActivitiesJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDActivitiesJSON401Response is a constructor method for a GetTripsTripIDActivities response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDActivitiesJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDActivitiesJSON403Response is a constructor method for a GetTripsTripIDActivities response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDActivitiesJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesJSON201Response is a constructor method for a PostTripsTripIDActivities response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesJSON201Response(body CreateActivityResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesJSON400Response is a constructor method for a PostTripsTripIDActivities response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesJSON401Response is a constructor method for a PostTripsTripIDActivities response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesJSON403Response is a constructor method for a PostTripsTripIDActivities response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesJSON409Response is a constructor method for a PostTripsTripIDActivities response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesJSON409Response(body ActivityConflictResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDActivitiesConflictsJSON200Response is a constructor method for a GetTripsTripIDActivitiesConflicts response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDActivitiesConflictsJSON200Response(body GetActivityConflictsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDActivitiesConflictsJSON400Response is a constructor method for a GetTripsTripIDActivitiesConflicts response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDActivitiesConflictsJSON400Response(body Error) *Response {
 	return &Response{
 		body:        body,
 		Code:        400,
@@ -450,63 +1261,1741 @@ func GetTripsTripIDParticipantsJSON400Response(body Error) *Response {
 	}
 }
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Confirms a participant on a trip.
-	// (PATCH /participants/{participantId}/confirm)
-	PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string) *Response
-	// Create a new trip
-	// (POST /trips)
-	PostTrips(w http.ResponseWriter, r *http.Request) *Response
-	// Get a trip details.
-	// (GET /trips/{tripId})
-	GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Update a trip.
-	// (PUT /trips/{tripId})
-	PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Get a trip activities.
-	// (GET /trips/{tripId}/activities)
-	GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Create a trip activity.
-	// (POST /trips/{tripId}/activities)
-	PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Confirm a trip and send e-mail invitations.
-	// (GET /trips/{tripId}/confirm)
-	GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Invite someone to the trip.
-	// (POST /trips/{tripId}/invites)
-	PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Get a trip links.
-	// (GET /trips/{tripId}/links)
-	GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Create a trip link.
-	// (POST /trips/{tripId}/links)
-	PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Get a trip participants.
-	// (GET /trips/{tripId}/participants)
-	GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string) *Response
-}
+// GetTripsTripIDActivitiesConflictsJSON401Response is a constructor method for a GetTripsTripIDActivitiesConflicts response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDActivitiesConflictsJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDActivitiesConflictsJSON403Response is a constructor method for a GetTripsTripIDActivitiesConflicts response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDActivitiesConflictsJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDActivitiesConflictsJSON404Response is a constructor method for a GetTripsTripIDActivitiesConflicts response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDActivitiesConflictsJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesImportJSON200Response is a constructor method for a PostTripsTripIDActivitiesImport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesImportJSON200Response(body ImportActivitiesResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesImportJSON201Response is a constructor method for a PostTripsTripIDActivitiesImport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesImportJSON201Response(body ImportActivitiesResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesImportJSON400Response is a constructor method for a PostTripsTripIDActivitiesImport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesImportJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesImportJSON401Response is a constructor method for a PostTripsTripIDActivitiesImport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesImportJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesImportJSON403Response is a constructor method for a PostTripsTripIDActivitiesImport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesImportJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDActivitiesImportJSON404Response is a constructor method for a PostTripsTripIDActivitiesImport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDActivitiesImportJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDActivitiesActivityIDJSON204Response is a constructor method for a DeleteTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDActivitiesActivityIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDActivitiesActivityIDJSON400Response is a constructor method for a DeleteTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDActivitiesActivityIDJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDActivitiesActivityIDJSON401Response is a constructor method for a DeleteTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDActivitiesActivityIDJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDActivitiesActivityIDJSON403Response is a constructor method for a DeleteTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDActivitiesActivityIDJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDActivitiesActivityIDJSON404Response is a constructor method for a DeleteTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDActivitiesActivityIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDActivitiesActivityIDJSON200Response is a constructor method for a PutTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDActivitiesActivityIDJSON200Response(body UpdateActivityResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDActivitiesActivityIDJSON400Response is a constructor method for a PutTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDActivitiesActivityIDJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDActivitiesActivityIDJSON401Response is a constructor method for a PutTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDActivitiesActivityIDJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDActivitiesActivityIDJSON403Response is a constructor method for a PutTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDActivitiesActivityIDJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDActivitiesActivityIDJSON404Response is a constructor method for a PutTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDActivitiesActivityIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDActivitiesActivityIDJSON409Response is a constructor method for a PutTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDActivitiesActivityIDJSON409Response(body ActivityConflictResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDCalendarIcsJSON400Response is a constructor method for a GetTripsTripIDCalendarIcs response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDCalendarIcsJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDCalendarIcsJSON401Response is a constructor method for a GetTripsTripIDCalendarIcs response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDCalendarIcsJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDCalendarIcsJSON403Response is a constructor method for a GetTripsTripIDCalendarIcs response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDCalendarIcsJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDCalendarIcsJSON404Response is a constructor method for a GetTripsTripIDCalendarIcs response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDCalendarIcsJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDCalendarFeedJSON204Response is a constructor method for a DeleteTripsTripIDCalendarFeed response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDCalendarFeedJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDCalendarFeedJSON400Response is a constructor method for a DeleteTripsTripIDCalendarFeed response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDCalendarFeedJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDCalendarFeedJSON401Response is a constructor method for a DeleteTripsTripIDCalendarFeed response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDCalendarFeedJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDCalendarFeedJSON403Response is a constructor method for a DeleteTripsTripIDCalendarFeed response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDCalendarFeedJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDCalendarFeedJSON404Response is a constructor method for a DeleteTripsTripIDCalendarFeed response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDCalendarFeedJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDCalendarFeedJSON200Response is a constructor method for a PostTripsTripIDCalendarFeed response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDCalendarFeedJSON200Response(body CalendarFeed) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDCalendarFeedJSON400Response is a constructor method for a PostTripsTripIDCalendarFeed response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDCalendarFeedJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDCalendarFeedJSON401Response is a constructor method for a PostTripsTripIDCalendarFeed response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDCalendarFeedJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDCalendarFeedJSON403Response is a constructor method for a PostTripsTripIDCalendarFeed response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDCalendarFeedJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDCalendarFeedJSON404Response is a constructor method for a PostTripsTripIDCalendarFeed response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDCalendarFeedJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDConfirmJSON204Response is a constructor method for a GetTripsTripIDConfirm response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDConfirmJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDConfirmJSON400Response is a constructor method for a GetTripsTripIDConfirm response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDConfirmJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDConfirmJSON404Response is a constructor method for a GetTripsTripIDConfirm response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDConfirmJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesJSON200Response is a constructor method for a PostTripsTripIDInvites response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesJSON200Response(body InviteParticipantsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesJSON400Response is a constructor method for a PostTripsTripIDInvites response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesJSON401Response is a constructor method for a PostTripsTripIDInvites response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesJSON403Response is a constructor method for a PostTripsTripIDInvites response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesBulkJSON200Response is a constructor method for a PostTripsTripIDInvitesBulk response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesBulkJSON200Response(body InviteParticipantsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesBulkJSON400Response is a constructor method for a PostTripsTripIDInvitesBulk response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesBulkJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesBulkJSON401Response is a constructor method for a PostTripsTripIDInvitesBulk response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesBulkJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesBulkJSON403Response is a constructor method for a PostTripsTripIDInvitesBulk response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesBulkJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesBulkJSON404Response is a constructor method for a PostTripsTripIDInvitesBulk response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesBulkJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDJoinLinksJSON200Response is a constructor method for a GetTripsTripIDJoinLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDJoinLinksJSON200Response(body GetJoinLinksResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDJoinLinksJSON400Response is a constructor method for a GetTripsTripIDJoinLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDJoinLinksJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDJoinLinksJSON401Response is a constructor method for a GetTripsTripIDJoinLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDJoinLinksJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDJoinLinksJSON403Response is a constructor method for a GetTripsTripIDJoinLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDJoinLinksJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDJoinLinksJSON404Response is a constructor method for a GetTripsTripIDJoinLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDJoinLinksJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDJoinLinksJSON201Response is a constructor method for a PostTripsTripIDJoinLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDJoinLinksJSON201Response(body JoinLink) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDJoinLinksJSON400Response is a constructor method for a PostTripsTripIDJoinLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDJoinLinksJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDJoinLinksJSON401Response is a constructor method for a PostTripsTripIDJoinLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDJoinLinksJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDJoinLinksJSON403Response is a constructor method for a PostTripsTripIDJoinLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDJoinLinksJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDJoinLinksJSON404Response is a constructor method for a PostTripsTripIDJoinLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDJoinLinksJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDJoinLinksLinkIDJSON204Response is a constructor method for a DeleteTripsTripIDJoinLinksLinkID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDJoinLinksLinkIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDJoinLinksLinkIDJSON400Response is a constructor method for a DeleteTripsTripIDJoinLinksLinkID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDJoinLinksLinkIDJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDJoinLinksLinkIDJSON401Response is a constructor method for a DeleteTripsTripIDJoinLinksLinkID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDJoinLinksLinkIDJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDJoinLinksLinkIDJSON403Response is a constructor method for a DeleteTripsTripIDJoinLinksLinkID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDJoinLinksLinkIDJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDJoinLinksLinkIDJSON404Response is a constructor method for a DeleteTripsTripIDJoinLinksLinkID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDJoinLinksLinkIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDLinksJSON200Response is a constructor method for a GetTripsTripIDLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDLinksJSON200Response(body GetLinksResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDLinksJSON400Response is a constructor method for a GetTripsTripIDLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDLinksJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDLinksJSON401Response is a constructor method for a GetTripsTripIDLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDLinksJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDLinksJSON403Response is a constructor method for a GetTripsTripIDLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDLinksJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDLinksJSON201Response is a constructor method for a PostTripsTripIDLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDLinksJSON201Response(body CreateLinkResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDLinksJSON400Response is a constructor method for a PostTripsTripIDLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDLinksJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDLinksJSON401Response is a constructor method for a PostTripsTripIDLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDLinksJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDLinksJSON403Response is a constructor method for a PostTripsTripIDLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDLinksJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDOwnerJSON204Response is a constructor method for a PostTripsTripIDOwner response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDOwnerJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDOwnerJSON400Response is a constructor method for a PostTripsTripIDOwner response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDOwnerJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDOwnerJSON401Response is a constructor method for a PostTripsTripIDOwner response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDOwnerJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDOwnerJSON403Response is a constructor method for a PostTripsTripIDOwner response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDOwnerJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDOwnerJSON404Response is a constructor method for a PostTripsTripIDOwner response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDOwnerJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDParticipantsJSON200Response is a constructor method for a GetTripsTripIDParticipants response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDParticipantsJSON200Response(body GetTripParticipantsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDParticipantsJSON400Response is a constructor method for a GetTripsTripIDParticipants response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDParticipantsJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDParticipantsJSON401Response is a constructor method for a GetTripsTripIDParticipants response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDParticipantsJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDParticipantsJSON403Response is a constructor method for a GetTripsTripIDParticipants response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDParticipantsJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDParticipantsParticipantIDJSON204Response is a constructor method for a DeleteTripsTripIDParticipantsParticipantID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDParticipantsParticipantIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDParticipantsParticipantIDJSON400Response is a constructor method for a DeleteTripsTripIDParticipantsParticipantID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDParticipantsParticipantIDJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDParticipantsParticipantIDJSON401Response is a constructor method for a DeleteTripsTripIDParticipantsParticipantID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDParticipantsParticipantIDJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDParticipantsParticipantIDJSON403Response is a constructor method for a DeleteTripsTripIDParticipantsParticipantID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDParticipantsParticipantIDJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDParticipantsParticipantIDJSON404Response is a constructor method for a DeleteTripsTripIDParticipantsParticipantID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDParticipantsParticipantIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDParticipantsParticipantIDResendJSON204Response is a constructor method for a PostTripsTripIDParticipantsParticipantIDResend response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDParticipantsParticipantIDResendJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDParticipantsParticipantIDResendJSON400Response is a constructor method for a PostTripsTripIDParticipantsParticipantIDResend response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDParticipantsParticipantIDResendJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDParticipantsParticipantIDResendJSON401Response is a constructor method for a PostTripsTripIDParticipantsParticipantIDResend response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDParticipantsParticipantIDResendJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDParticipantsParticipantIDResendJSON403Response is a constructor method for a PostTripsTripIDParticipantsParticipantIDResend response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDParticipantsParticipantIDResendJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDParticipantsParticipantIDResendJSON404Response is a constructor method for a PostTripsTripIDParticipantsParticipantIDResend response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDParticipantsParticipantIDResendJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDParticipantsParticipantIDResendJSON429Response is a constructor method for a PostTripsTripIDParticipantsParticipantIDResend response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDParticipantsParticipantIDResendJSON429Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        429,
+		contentType: "application/json",
+	}
+}
+
+// PatchTripsTripIDParticipantsParticipantIDRoleJSON204Response is a constructor method for a PatchTripsTripIDParticipantsParticipantIDRole response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchTripsTripIDParticipantsParticipantIDRoleJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PatchTripsTripIDParticipantsParticipantIDRoleJSON400Response is a constructor method for a PatchTripsTripIDParticipantsParticipantIDRole response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchTripsTripIDParticipantsParticipantIDRoleJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PatchTripsTripIDParticipantsParticipantIDRoleJSON401Response is a constructor method for a PatchTripsTripIDParticipantsParticipantIDRole response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchTripsTripIDParticipantsParticipantIDRoleJSON401Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        401,
+		contentType: "application/json",
+	}
+}
+
+// PatchTripsTripIDParticipantsParticipantIDRoleJSON403Response is a constructor method for a PatchTripsTripIDParticipantsParticipantIDRole response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchTripsTripIDParticipantsParticipantIDRoleJSON403Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        403,
+		contentType: "application/json",
+	}
+}
+
+// PatchTripsTripIDParticipantsParticipantIDRoleJSON404Response is a constructor method for a PatchTripsTripIDParticipantsParticipantIDRole response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchTripsTripIDParticipantsParticipantIDRoleJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List outbox e-mails.
+	// (GET /admin/emails)
+	GetAdminEmails(w http.ResponseWriter, r *http.Request, params GetAdminEmailsParams) *Response
+	// Retry a dead outbox e-mail.
+	// (POST /admin/emails/{emailId}/retry)
+	PostAdminEmailsEmailIDRetry(w http.ResponseWriter, r *http.Request, emailID string) *Response
+	// Send a sign-in link by e-mail.
+	// (POST /auth/magic-link)
+	PostAuthMagicLink(w http.ResponseWriter, r *http.Request) *Response
+	// Start a session from a sign-in link.
+	// (GET /auth/session)
+	GetAuthSession(w http.ResponseWriter, r *http.Request, params GetAuthSessionParams) *Response
+	// Get a trip calendar through a calendar feed.
+	// (GET /calendar/{token}.ics)
+	GetCalendarTokenIcs(w http.ResponseWriter, r *http.Request, token string) *Response
+	// Open the page to confirm a trip or a participant from an e-mail link.
+	// (GET /confirm)
+	GetConfirm(w http.ResponseWriter, r *http.Request, params GetConfirmParams) *Response
+	// Get the trip of a join link.
+	// (GET /join/{code})
+	GetJoinCode(w http.ResponseWriter, r *http.Request, code string) *Response
+	// Join a trip through a join link.
+	// (POST /join/{code})
+	PostJoinCode(w http.ResponseWriter, r *http.Request, code string) *Response
+	// Confirms a participant on a trip.
+	// (PATCH /participants/{participantId}/confirm)
+	PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string, params PatchParticipantsParticipantIDConfirmParams) *Response
+	// Declines an invitation to a trip.
+	// (PATCH /participants/{participantId}/decline)
+	PatchParticipantsParticipantIDDecline(w http.ResponseWriter, r *http.Request, participantID string, params PatchParticipantsParticipantIDDeclineParams) *Response
+	// Create a new trip
+	// (POST /trips)
+	PostTrips(w http.ResponseWriter, r *http.Request) *Response
+	// Get a trip details.
+	// (GET /trips/{tripId})
+	GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Update a trip.
+	// (PUT /trips/{tripId})
+	PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get a trip activities.
+	// (GET /trips/{tripId}/activities)
+	GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDActivitiesParams) *Response
+	// Create a trip activity.
+	// (POST /trips/{tripId}/activities)
+	PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string, params PostTripsTripIDActivitiesParams) *Response
+	// List the overlapping activities of a trip.
+	// (GET /trips/{tripId}/activities/conflicts)
+	GetTripsTripIDActivitiesConflicts(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Import trip activities from an iCalendar file.
+	// (POST /trips/{tripId}/activities/import)
+	PostTripsTripIDActivitiesImport(w http.ResponseWriter, r *http.Request, tripID string, params PostTripsTripIDActivitiesImportParams) *Response
+	// Delete a trip activity.
+	// (DELETE /trips/{tripId}/activities/{activityId})
+	DeleteTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string, params DeleteTripsTripIDActivitiesActivityIDParams) *Response
+	// Update a trip activity.
+	// (PUT /trips/{tripId}/activities/{activityId})
+	PutTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string, params PutTripsTripIDActivitiesActivityIDParams) *Response
+	// Get a trip calendar.
+	// (GET /trips/{tripId}/calendar.ics)
+	GetTripsTripIDCalendarIcs(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Revoke the caller calendar feed of a trip.
+	// (DELETE /trips/{tripId}/calendar/feed)
+	DeleteTripsTripIDCalendarFeed(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get the caller calendar feed of a trip.
+	// (POST /trips/{tripId}/calendar/feed)
+	PostTripsTripIDCalendarFeed(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Confirm a trip and send e-mail invitations.
+	// (GET /trips/{tripId}/confirm)
+	GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDConfirmParams) *Response
+	// Invite someone to the trip.
+	// (POST /trips/{tripId}/invites)
+	PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Invite many people to the trip.
+	// (POST /trips/{tripId}/invites/bulk)
+	PostTripsTripIDInvitesBulk(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get a trip join links.
+	// (GET /trips/{tripId}/join-links)
+	GetTripsTripIDJoinLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Create a trip join link.
+	// (POST /trips/{tripId}/join-links)
+	PostTripsTripIDJoinLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Revoke a trip join link.
+	// (DELETE /trips/{tripId}/join-links/{linkId})
+	DeleteTripsTripIDJoinLinksLinkID(w http.ResponseWriter, r *http.Request, tripID string, linkID string) *Response
+	// Get a trip links.
+	// (GET /trips/{tripId}/links)
+	GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Create a trip link.
+	// (POST /trips/{tripId}/links)
+	PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Transfer the trip ownership.
+	// (POST /trips/{tripId}/owner)
+	PostTripsTripIDOwner(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get a trip participants.
+	// (GET /trips/{tripId}/participants)
+	GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDParticipantsParams) *Response
+	// Remove a participant from a trip.
+	// (DELETE /trips/{tripId}/participants/{participantId})
+	DeleteTripsTripIDParticipantsParticipantID(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *Response
+	// Send the invitation e-mail again.
+	// (POST /trips/{tripId}/participants/{participantId}/resend)
+	PostTripsTripIDParticipantsParticipantIDResend(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *Response
+	// Change the role of a participant.
+	// (PATCH /trips/{tripId}/participants/{participantId}/role)
+	PatchTripsTripIDParticipantsParticipantIDRole(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *Response
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler          ServerInterface
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// GetAdminEmails operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminEmails(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminEmailsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status); err != nil {
+		err = fmt.Errorf("invalid format for parameter status: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "status"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetAdminEmails(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostAdminEmailsEmailIDRetry operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminEmailsEmailIDRetry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "emailId" -------------
+	var emailID string
+
+	if err := runtime.BindStyledParameter("simple", false, "emailId", chi.URLParam(r, "emailId"), &emailID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "emailId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostAdminEmailsEmailIDRetry(w, r, emailID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostAuthMagicLink operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthMagicLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostAuthMagicLink(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetAuthSession operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuthSessionParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetAuthSession(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetCalendarTokenIcs operation middleware
+func (siw *ServerInterfaceWrapper) GetCalendarTokenIcs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	if err := runtime.BindStyledParameter("simple", false, "token", chi.URLParam(r, "token"), &token); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetCalendarTokenIcs(w, r, token)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetConfirm operation middleware
+func (siw *ServerInterfaceWrapper) GetConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetConfirmParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetConfirm(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetJoinCode operation middleware
+func (siw *ServerInterfaceWrapper) GetJoinCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	if err := runtime.BindStyledParameter("simple", false, "code", chi.URLParam(r, "code"), &code); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "code"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetJoinCode(w, r, code)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostJoinCode operation middleware
+func (siw *ServerInterfaceWrapper) PostJoinCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	if err := runtime.BindStyledParameter("simple", false, "code", chi.URLParam(r, "code"), &code); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "code"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostJoinCode(w, r, code)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchParticipantsParticipantIDConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchParticipantsParticipantIDConfirmParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchParticipantsParticipantIDConfirm(w, r, participantID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchParticipantsParticipantIDDecline operation middleware
+func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDDecline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchParticipantsParticipantIDDeclineParams
+
+	// ------------- Optional query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchParticipantsParticipantIDDecline(w, r, participantID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTrips operation middleware
+func (siw *ServerInterfaceWrapper) PostTrips(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTrips(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripID operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripID(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutTripsTripID operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutTripsTripID(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDActivities operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDActivitiesParams
+
+	// ------------- Optional query parameter "category" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category); err != nil {
+		err = fmt.Errorf("invalid format for parameter category: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "category"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDActivities(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTripsTripIDActivities operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTripsTripIDActivitiesParams
+
+	// ------------- Optional query parameter "strict" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "strict", r.URL.Query(), &params.Strict); err != nil {
+		err = fmt.Errorf("invalid format for parameter strict: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "strict"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTripsTripIDActivities(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDActivitiesConflicts operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDActivitiesConflicts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDActivitiesConflicts(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTripsTripIDActivitiesImport operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDActivitiesImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTripsTripIDActivitiesImportParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "dry_run", r.URL.Query(), &params.DryRun); err != nil {
+		err = fmt.Errorf("invalid format for parameter dry_run: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "dry_run"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTripsTripIDActivitiesImport(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteTripsTripIDActivitiesActivityID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "activityId" -------------
+	var activityID string
+
+	if err := runtime.BindStyledParameter("simple", false, "activityId", chi.URLParam(r, "activityId"), &activityID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "activityId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteTripsTripIDActivitiesActivityIDParams
+
+	// ------------- Optional query parameter "scope" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "scope", r.URL.Query(), &params.Scope); err != nil {
+		err = fmt.Errorf("invalid format for parameter scope: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "scope"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTripsTripIDActivitiesActivityID(w, r, tripID, activityID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutTripsTripIDActivitiesActivityID operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "activityId" -------------
+	var activityID string
+
+	if err := runtime.BindStyledParameter("simple", false, "activityId", chi.URLParam(r, "activityId"), &activityID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "activityId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutTripsTripIDActivitiesActivityIDParams
+
+	// ------------- Optional query parameter "strict" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "strict", r.URL.Query(), &params.Strict); err != nil {
+		err = fmt.Errorf("invalid format for parameter strict: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "strict"})
+		return
+	}
+
+	// ------------- Optional query parameter "scope" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "scope", r.URL.Query(), &params.Scope); err != nil {
+		err = fmt.Errorf("invalid format for parameter scope: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "scope"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutTripsTripIDActivitiesActivityID(w, r, tripID, activityID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDCalendarIcs operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDCalendarIcs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler          ServerInterface
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDCalendarIcs(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
 }
 
-// PatchParticipantsParticipantIDConfirm operation middleware
-func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request) {
+// DeleteTripsTripIDCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDCalendarFeed(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// ------------- Path parameter "participantId" -------------
-	var participantID string
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
 
-	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PatchParticipantsParticipantIDConfirm(w, r, participantID)
+		resp := siw.Handler.DeleteTripsTripIDCalendarFeed(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -519,12 +3008,24 @@ func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDConfirm(w http.
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTrips operation middleware
-func (siw *ServerInterfaceWrapper) PostTrips(w http.ResponseWriter, r *http.Request) {
+// PostTripsTripIDCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDCalendarFeed(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTrips(w, r)
+		resp := siw.Handler.PostTripsTripIDCalendarFeed(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -537,8 +3038,8 @@ func (siw *ServerInterfaceWrapper) PostTrips(w http.ResponseWriter, r *http.Requ
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripID operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http.Request) {
+// GetTripsTripIDConfirm operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -549,8 +3050,19 @@ func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDConfirmParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripID(w, r, tripID)
+		resp := siw.Handler.GetTripsTripIDConfirm(w, r, tripID, params)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -563,8 +3075,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
-// PutTripsTripID operation middleware
-func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http.Request) {
+// PostTripsTripIDInvites operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -575,8 +3087,12 @@ func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PutTripsTripID(w, r, tripID)
+		resp := siw.Handler.PostTripsTripIDInvites(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -589,8 +3105,8 @@ func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDActivities operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+// PostTripsTripIDInvitesBulk operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDInvitesBulk(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -601,8 +3117,12 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWrite
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDActivities(w, r, tripID)
+		resp := siw.Handler.PostTripsTripIDInvitesBulk(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -615,8 +3135,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWrite
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDActivities operation middleware
-func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+// GetTripsTripIDJoinLinks operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDJoinLinks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -627,8 +3147,12 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWrit
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTripsTripIDActivities(w, r, tripID)
+		resp := siw.Handler.GetTripsTripIDJoinLinks(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -641,8 +3165,8 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWrit
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDConfirm operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request) {
+// PostTripsTripIDJoinLinks operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDJoinLinks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -653,8 +3177,12 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter,
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDConfirm(w, r, tripID)
+		resp := siw.Handler.PostTripsTripIDJoinLinks(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -667,8 +3195,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter,
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDInvites operation middleware
-func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request) {
+// DeleteTripsTripIDJoinLinksLinkID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDJoinLinksLinkID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -679,8 +3207,20 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter,
 		return
 	}
 
+	// ------------- Path parameter "linkId" -------------
+	var linkID string
+
+	if err := runtime.BindStyledParameter("simple", false, "linkId", chi.URLParam(r, "linkId"), &linkID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "linkId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTripsTripIDInvites(w, r, tripID)
+		resp := siw.Handler.DeleteTripsTripIDJoinLinksLinkID(w, r, tripID, linkID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -705,6 +3245,10 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDLinks(w http.ResponseWriter, r
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resp := siw.Handler.GetTripsTripIDLinks(w, r, tripID)
 		if resp != nil {
@@ -731,6 +3275,10 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDLinks(w http.ResponseWriter, r
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resp := siw.Handler.PostTripsTripIDLinks(w, r, tripID)
 		if resp != nil {
@@ -745,6 +3293,36 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDLinks(w http.ResponseWriter, r
 	handler(w, r.WithContext(ctx))
 }
 
+// PostTripsTripIDOwner operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDOwner(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTripsTripIDOwner(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetTripsTripIDParticipants operation middleware
 func (siw *ServerInterfaceWrapper) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -757,8 +3335,137 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDParticipants(w http.ResponseWri
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDParticipantsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status); err != nil {
+		err = fmt.Errorf("invalid format for parameter status: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "status"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDParticipants(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteTripsTripIDParticipantsParticipantID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDParticipantsParticipantID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTripsTripIDParticipantsParticipantID(w, r, tripID, participantID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTripsTripIDParticipantsParticipantIDResend operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDParticipantsParticipantIDResend(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTripsTripIDParticipantsParticipantIDResend(w, r, tripID, participantID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchTripsTripIDParticipantsParticipantIDRole operation middleware
+func (siw *ServerInterfaceWrapper) PatchTripsTripIDParticipantsParticipantIDRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDParticipants(w, r, tripID)
+		resp := siw.Handler.PatchTripsTripIDParticipantsParticipantIDRole(w, r, tripID, participantID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -886,17 +3593,41 @@ func Handler(si ServerInterface, opts ...ServerOption) http.Handler {
 	}
 
 	r.Route(options.BaseURL, func(r chi.Router) {
+		r.Get("/admin/emails", wrapper.GetAdminEmails)
+		r.Post("/admin/emails/{emailId}/retry", wrapper.PostAdminEmailsEmailIDRetry)
+		r.Post("/auth/magic-link", wrapper.PostAuthMagicLink)
+		r.Get("/auth/session", wrapper.GetAuthSession)
+		r.Get("/calendar/{token}.ics", wrapper.GetCalendarTokenIcs)
+		r.Get("/confirm", wrapper.GetConfirm)
+		r.Get("/join/{code}", wrapper.GetJoinCode)
+		r.Post("/join/{code}", wrapper.PostJoinCode)
 		r.Patch("/participants/{participantId}/confirm", wrapper.PatchParticipantsParticipantIDConfirm)
+		r.Patch("/participants/{participantId}/decline", wrapper.PatchParticipantsParticipantIDDecline)
 		r.Post("/trips", wrapper.PostTrips)
 		r.Get("/trips/{tripId}", wrapper.GetTripsTripID)
 		r.Put("/trips/{tripId}", wrapper.PutTripsTripID)
 		r.Get("/trips/{tripId}/activities", wrapper.GetTripsTripIDActivities)
 		r.Post("/trips/{tripId}/activities", wrapper.PostTripsTripIDActivities)
+		r.Get("/trips/{tripId}/activities/conflicts", wrapper.GetTripsTripIDActivitiesConflicts)
+		r.Post("/trips/{tripId}/activities/import", wrapper.PostTripsTripIDActivitiesImport)
+		r.Delete("/trips/{tripId}/activities/{activityId}", wrapper.DeleteTripsTripIDActivitiesActivityID)
+		r.Put("/trips/{tripId}/activities/{activityId}", wrapper.PutTripsTripIDActivitiesActivityID)
+		r.Get("/trips/{tripId}/calendar.ics", wrapper.GetTripsTripIDCalendarIcs)
+		r.Delete("/trips/{tripId}/calendar/feed", wrapper.DeleteTripsTripIDCalendarFeed)
+		r.Post("/trips/{tripId}/calendar/feed", wrapper.PostTripsTripIDCalendarFeed)
 		r.Get("/trips/{tripId}/confirm", wrapper.GetTripsTripIDConfirm)
 		r.Post("/trips/{tripId}/invites", wrapper.PostTripsTripIDInvites)
+		r.Post("/trips/{tripId}/invites/bulk", wrapper.PostTripsTripIDInvitesBulk)
+		r.Get("/trips/{tripId}/join-links", wrapper.GetTripsTripIDJoinLinks)
+		r.Post("/trips/{tripId}/join-links", wrapper.PostTripsTripIDJoinLinks)
+		r.Delete("/trips/{tripId}/join-links/{linkId}", wrapper.DeleteTripsTripIDJoinLinksLinkID)
 		r.Get("/trips/{tripId}/links", wrapper.GetTripsTripIDLinks)
 		r.Post("/trips/{tripId}/links", wrapper.PostTripsTripIDLinks)
+		r.Post("/trips/{tripId}/owner", wrapper.PostTripsTripIDOwner)
 		r.Get("/trips/{tripId}/participants", wrapper.GetTripsTripIDParticipants)
+		r.Delete("/trips/{tripId}/participants/{participantId}", wrapper.DeleteTripsTripIDParticipantsParticipantID)
+		r.Post("/trips/{tripId}/participants/{participantId}/resend", wrapper.PostTripsTripIDParticipantsParticipantIDResend)
+		r.Patch("/trips/{tripId}/participants/{participantId}/role", wrapper.PatchTripsTripIDParticipantsParticipantIDRole)
 	})
 	return r
 }
@@ -922,30 +3653,108 @@ func WithErrorHandler(handler func(w http.ResponseWriter, r *http.Request, err e
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+Raz27bOBN/FYLfd5TjdDcnAXtom6LwItgGRRd7KIqAlsY2E4lUyZFTw/DT7GFPe9wn",
-	"yIstSMo2Jcs2rcSbOr20tiJy/vxmfjNDek4TmRdSgEBN4znVyQRyZj++VcAQXifIpxxnH+FrCRrNH1ia",
-	"cuRSsOxayQIUctA0HrFMQ0QL79GcyiQplb5hdt1Iqtx8oilD6CHPgUYUZwXQmGpUXIxpRL/1xrIH31Cx",
-	"HrKx3WTKMm6W0Jgq+FpyBSldLCKKHDMwL3TeYxGtv8WfPW2Xm39ZKSiHt5AgXUQbftGFFBoOdAyrlg/S",
-	"mmfKkqcbTmmq6a3drt8VF3fdMHu8WyNaqqxul+KdsY7MZhtYOS2dpH1e6IRQxsVdF3Sqddt1+qR40Q2Z",
-	"FDRywczb5mvOxRWIMU5ofNHZuTkXv1xYIyBnPNM3KG+4mHK0/uIIua75wL616YTVA6YUm4WLT/kUIren",
-	"1UGkx2ILeS9A3ThR+w0KNmCtuxMgWP7Y5NHIFB7HDY1Y9QPKl7sGoiUsapbW/bov6DslIipedEnEal2b",
-	"Tu+UkmqvGinoRPHCpRt9w1KiqrRtqpiD1mzcgntTp+WLbUq9BzR0pR/BV7qWs/9XMKIx/V9/XeL7VX3v",
-	"N4W9tmnbTOM2btNByrv9DrOAh4C8tewHVp2mSU7GnmLyHtAEcFXzOejHVf2lvYFAtYv+UCKoMNg8sQdZ",
-	"NxBiKeIoSB7aHe4AfxeqazEHWe85+PlQ9iDYQDmijuDDfNekfmapPCw0LgFNEXgEgQc6oCHIPPowvG2l",
-	"9gP0XW5ztG7r4M5lEYXmCNc3iRQjrnJIvbgfSpkBE7RDu9CaKyGdQE2VHd6/Zgp5wgsmsGvIFN4WhyZR",
-	"m/gwnqxJPdDALkQR2oyuoqVDdCz7UVFmGRsa7kRVQlBMVA3eUqe98A9sf+g5p9uUc7QWvWHj9pb19yL9",
-	"nue0481I39PksQmM2YOLkaxc7PXm73QBCR/xhD389fAPaJIy8vp6QAqmGJFkyJK7HojUPGZF5l77U5Ii",
-	"Y0KcgSKJFBpV+fB3ykhaKiYQiCS/Xf1BfpWlEjAzKz/K5A5QA8OzVXMR0+UeNKJTUNrp8+rs/OzcdjgF",
-	"CFZwGtOf7aOIFgwn1k19n236c+/bIF30q0xzXIjJxB6mFaCsx8w0RK/NY5+JvM+Dy7fVeiNQsRwQlKbx",
-	"5znlRj+jxDLBY1oTTX2cHFU4fg0ZwL6YxY4PrY0/nV+Y/xIpEITLosL631jRv9UuP9b7gyhzEx2GrEwA",
-	"1EnLBkAd+EsYsTJDsiozi4henJ8fJHRXSXGDYotgfxq0mVPmOVMzGtPK85ow4jmWSEEYMX2LDR6bKs2C",
-	"Y/bpm1dcCZSOdxqoS21LkK5wAo1vZDp7MoM3j6gaqWuB2ID51VEUWGJ6GrhbxQkjAu4t0B7ODlQP4P7c",
-	"nU4sjCJjaAG6ajW0+WdwGZTH1YHH0ybw0/l0yyxxGui+B6zyl6TOgLMWfCNalG1JWz4blk/PEJvNURBD",
-	"/HiFwDmqhfW3s0G/fnRQEUNd4KcJ10TJEoHc8ywjCrBUgrAsIzgBYmRqMgS8BxD2iQ3aVYdFmEhJ1WO5",
-	"lyMCU/uq1GZLnMgSyVoRo/kualqfWbwgkmo56Ts5nqpDuAw+/8DH8NXuLuNZIT5Wd9O80n6WDmfj/vjE",
-	"uhw/xGZbA6yF4rzJJqDxOWSOOQq1/LADzApjkRJthmfo5YxnxN4CWlV0YFFz94YhQ43DfFC9f9pcs/U0",
-	"7Ah08xLCzvmLaJmDFEBQrpqXkIl5HW2re9AAdrFXli+kbanfHZ9ct2Jh85Gu7ppDe5T/HspjtSf+L7ee",
-	"pTWp/WjqFNsSEzptodTCFs2LpgDS8M9cX9DI03prd3I04uO5q24sFv8GAAD//6I5Fyf6KgAA",
+	"H4sIAAAAAAAC/+x9y3LbOLrwq6D4/1WzoWUnk5zpeKoXzmW63SeTdNnJZJFKuWDyk4Q2CXAA0Lbapac5",
+	"i7M6y/ME/WKncCPBm0RRku24ubIskcAH4LvfcBdELM0YBSpFcHwXiGgOKdYfTyJJrolcvGF0mpBIqu9w",
+	"HBNJGMXJr5xlwCUBERxPcSIgDGIQESeZ+j04Dj7dMITNEAQEYlOEkeQkQzdzJgBJkqpvr4EnOAuRnAMC",
+	"zBMCQqIp4UJOgjDIvEnuAv21+vD/OUyD4+D/HZawH1rAD38C+YmT7KSY+QxExqiAU0qBn3COF8EyDARE",
+	"jMY7GWwZBhz+nRMOcXD81UJZzPAtDOQig+A4YJe/QSTV5PWddaNuusNzcDu8cDspEJNz4Hq/1Z6qHW9s",
+	"ZGTn1f9UxzypnJgbwY2eETrTX87INVDEKKhTIhJSsaNzsXuF3f8pCIFnemfsT0JyQmeNbXcPht7qVu+9",
+	"2BSj3wlJUiwhRhETUmM0LQ6gia44ZTnVc0wZT7EMjoOY5ZeJgjEllKR5GhwfFSDSPL0EHoTB7cGMHcCt",
+	"5PhA4pke6honJMZSPZYS+uORXn2Ucw40WjQP8fT8I3rx/NnfkHsERSyGEMFkNkGvz95PgmJWu5vrZnU7",
+	"HRLB1MjBsr7/drUeWKt2/z2LsAF2oxP4MgcO/q6jOc4yoGKC3mNJZB6rX2OUMDoz/80YkmwGiiaaJ5TY",
+	"dzrOCN+aM3p15B3YwauNj4ylikIyuQjV4akBUnz74ytzigWoa4B49kMFimc/bAvGsx8MHM9+MIBQnGoY",
+	"Unz7HuhMzoPj5y9fDkYUNfTzly+biKLnWYUbZ2BRaFN++DO7qeAGhwywFCjOueNbiplNUIVxEikgmSIi",
+	"9AOaeyMWORBapJBaTDvdfaSguEKMSbJAjKMbgKtksQW1MQps+qMZzwymj4pQCfwaJ00IzvSSEVwDXyD3",
+	"GIrxQjh4RIieocsFimGK80RL2QKrnhWAqldnG+PUMw1dTiVpAe09FhKlLAUqkWT2cBCWYSllgMbiAssa",
+	"eCVRYAkHSm9obOgyDNTa1DqbE7/Fi0KYqae82Rm1TDFlNMaLEE0ZL55LFqg4a4Mzdg41mMaQFmALWdgA",
+	"sCLZNthXfPvj38KYXIPFBgMqkjkI9fcGYmo+yXnO9YcpJ+qPwDLn+kOu3mhSYonJbeT4Ok+uTuk1kfAr",
+	"5pJEJMN0vcSs0gqk2KBCcYbmm8EEYV7fHbuq7nMrvzJTrt6hM7WRYtPdycp91f/30qPaj2WpJcWpGeDl",
+	"0dGG6FZybUXFei/UIArrmhtSAbttX97gBGiM+T8A4g1Z+AkSEHGQ6PPZexTZcRDOMoEiTJHIL9XTl4Ak",
+	"m6ATumAUlD2Brii7EYhI/RQHHJdMxY3S5OQRBywhvsA1JW0Vm8l5sl4XVQ+F/vCtu8TolPDUO8VhWOQI",
+	"YRhFbSCfLcCf2BXQ3hZLJ8afxpVNz3MSt+23kFjmLUz9XH/v2Lo3LsJTCYaJRwZgrWiG7j+ItSTERCZE",
+	"KF3+Zg60xBYi0DRPkkkbLOqBXmDXdtS+17qnGkdKzWcIAkRYwozxbn1kylgcIskxFRnjMkQJi2eEzkIk",
+	"yGwuBYBSjxg3puPE/KnJtaFs1cgsBUEJgJu/Or2a1Ng1bL2VX7HilmEQ51wf80VKaC6hBWGUcqh0bSR9",
+	"5S/BQooQYaEVx0QCp1iSa8VfnDayBw3JjtwE8ovDxQJA9egEvcH0LxJdOrvbWTTohsg5qq+9v86UeFZY",
+	"n90urDYlf5ndZl8AHx0d1afZUAIfHRlrpNCverLmDfidIk7fwuizcs8mUYyApPA7o9BieZ98ONGuLaR+",
+	"R4qXWg3zJAVOInx4jtnFrzhP2DZUVQCw1NDIZKcSoNx7N3gf1jVIIDg87ykNtndcUbi5D7dVaUCexi3A",
+	"nr7VUBozrXy2lEV1GzZE5U6hS3CrsdZqbT2rTQ/t/OQExGncBOwcZDcQk7WSzjvOdX44gz6/MELfE3o1",
+	"TPLBbUY4tLNSZa8lhF4hIVkm0A3jV2rXnHZAhLVFJ+iDOgZkxzKrV5QmIe7PSVN8e5GLLrmTYrpAGbAs",
+	"Aa2g/saI2mHO8tkcSQvoBH2mCUlJoZN4QOxU/iw7T2P4SWzPgwrVumQCnAw3FNVgDcZmoDQzfVuzC4PY",
+	"mTrHIRqifa8bJsWDhp1MDEISWoj5lFAnrV8M9+0R+uMLvQhtHIsLyS6ItkkrZuwao3851EjVzpDSE+Bp",
+	"U7tWFJSClLSI+ff6eydo4EDvAhJAZeFBUtInRJk8eH22U1XajAj04PN5sLScp+5IqPN0LIWGy38uRJRJ",
+	"FCmnvZMm7IYCvzc2FAZ6vos9+ojMBFsbyNoK5XJfSPaYtMkaX/KZh78LJdG1sIDKvldPeR2DG8R092CW",
+	"v1PQfszlJdM+tQ0BwlJvrK+LOTJZhoO8TqSfenxFTEi78YMycy+Ac8bVzzRPEqzCS8eS59AyDoVbeWFX",
+	"sRGcHoO5aId57dwcIpIRqAVOHUtY+7oAuhLk9QMUDqdW/8+wZdUwT7+iD6sctLF5BShhiVCVk2yeUwW5",
+	"yr3wN7UV2x1ibOCofY1jxK1C0lQ5pGIJvV3aev63+qWdpAB0rtHOsRk1Twkk7VTVGzAzRLgSwJ9A1tNC",
+	"xECGWLGSe51AfebmMdRWtNq2+wmkx0EHrsLIlf5IVGPZ61Zgh+8Av7RLB8FeU7obmLOZyjpAAalyq/Vy",
+	"0DCc9eJ+zXaJLeym/kftZlt7xmbYDpjvD976ZB1eo8HAm/E2W0FPjaLDru9prbfKvXVGeKfHbZhrkcBG",
+	"B9U+9cdcdjr72l1gatqNVuf5Ex82/NPud908JLOOx63VxHqi6E6CGGuB2TQeUXhZLQvu42YlOgrluYN1",
+	"zqwJVugjMn7eFiItDcjeFLyKNKsBADu4b/OZfQtL3NsI1T1qejiSXu2/NzZyv5Ou28xY28D9+IDRSMUW",
+	"lm/PDahNpL76ePlbqy6wAbxumL25JIfoSj35BhEXRUqARx+XjCWAaVDx/rX6+3frdfuQJ4nhCbnzv026",
+	"uaTnTxiiGnYzjDa20McLVNnNCtewu9iyZyvwzMuKEdvnmmzMLtqm7yf+t1ngIOnvNn0rUdvXB1tQV/Nr",
+	"7fu7iFzKe0cQTD+mManwmt8AB+06nwRt6G0GjlenS5g4aGNwdINFMfRAJWQ9l1AemSqUw6Zyfuq1D3KW",
+	"QKe2F7EDxmeYkt+BhygFlRqulLtrAjcd2l1XjpUd0a7Ny6AKvfypEMUQJYSavCoOKbuGuHWa8p3Vh+kn",
+	"c6lIKZhkPvf6BH0pBvKfFQhzQBlnKVO/6AgrEYjx2Cx7yKG0cUPr1nYEUmN8FXq0R+V58jw8aWJOjYrq",
+	"O9bGTU5TpdLv2FbqTKyw3kU05SzVZ1JkaMI1aOmmUuvmJJqjG5YnKlNBnQNGMV8gntPeWRdmWRA7nb1V",
+	"ReOLC57TdqIUVyTLDMX2mvDcPO8yV9+p5azl9Q6CirJXzt19Xt7CNkyP9aobKoeBa0fRTHbdkTlWhUe9",
+	"1DjhtSPt1eoaZAyFQd62vM+nb12Mt7m9PVhF3mlVlYqTpymZfWlFm3rC98B8mX2FWnunzLcsROTJvtah",
+	"MCiXEUtXiEtDRSHCCQccLxw/1v4QrZn3i3M1k490ppnKKyJC6/reOyGihbZfJhC4EiQ97V/EZGPx5DbB",
+	"rbkBZ68TGSpDuD7J/qp2Fyqs47punrbFFJ7hTZXouJ0rDInVVpPStuG1K7PM1huGHK7Z1ZY6aXu5Qxg4",
+	"KOqztnFBvbthsyiislXe4uzolQV0HfbwvKh7KE3aS+VkuIK7ljsyiII7eWXBJBlv8Mk6E1Mml30GsbKw",
+	"oicb3UUUqxFHd+tq27J/4hmJtshAfXCJeg5CEDa0IKcHs2oeiSoAanN4aUCQ/lnJU8GQAGkMMUC/sZxT",
+	"WFwI+1jE2BWBCToHGiMidQkGugTMgZshDGaZx7Rtp0ogMEVMT7heAzNgVphM6/61af6bFq1VtUNrAhGh",
+	"ICZW429q5Byw6IgPizxNMV+0/rZrTdUoqW7G0IHVtlefOKZiCvyj0lDEfCjrXac9vSkqtbwnkZyrYltQ",
+	"lCzKVH7jPFX6lomaSPNV8SCuOEQqfoBWx3D/BGP19qrayC6F63MWjyVfY8nXWPJ1ryVfY8lWC+vZPrts",
+	"UA1WrsGI91+HtVHSmtkc3x5lycDC/p066DdsFuIP70Y3QzexRsPZvRdqwzduUfVQEfsBjaLWhOjLTXiU",
+	"lTf7q3rZfXDb1890cBsRga4gk5USk1D3bjqyQSSBSFUQH20piI2UEXMylRerAh7/1JOb2kzNvwrJfLnQ",
+	"ixI4BRRDIrHSIop4uEe6fiRiLB3pnUtq+vLlnMjFueIG5nSMUXiSy3lzmSfI2ZPGZmRcaXU/nz9/+R/o",
+	"ly+fjLaEKdL2M4oSTFK1XM1r9EHpscsNmEuZmYQ3ZXa6OYmaynzlAoDHQc2gLcfAGflPsO12CJ2yJtTv",
+	"RAYRmZII//Hff/wvCBRjdPLrqSImjBi6xNHVgTKLY4xwlpjH/ouhLMGUToCrQKyQPP/jf2KsVUEqATH0",
+	"4f0X9IsBSr15xqIrkAIsYhqNI3BjBGFwDVwYeJ5NjiZHatksA4ozEhwHf9VfhUGG5VyfwiGOU0IPy6zs",
+	"GbRos++JkKJSFGcVAaYzs815lA0ETVxUhZCxVgdEzQRRG5KCBC6C46/2HP6dA1+Ux1CEVo38aOP/3xRm",
+	"GhmiIX9+dGQVGemM/Uxvs1rE4W/WKC/HWyOt2lLd9eHX2lCZZaHymTB4sUNITBVHy8R+qYae89n+5/xM",
+	"cS7njJPfITaT/nX/k/6D8UsSx0ArnERjjs9Dvn5bhncVCv/6bfmtdIEYLHYYa/FY05DmhF8DTQnBNzVJ",
+	"hSoO7/Tf03h5yEEa6zyzlm0bJivaKhHZvhv4bNR45cttWVfg1sT0FxttO1AlaL/quIDiz9X4wIjUD4vU",
+	"asYX+5/xA1NVzDmNtyWjM0UECFvu7pNTJzXlcn6YKpf8QWLDeI6AagoomdEDQnXjBJP4o4uvsUSpbs1K",
+	"I3Aa3A1D1kuiPDyF/mYACVHKODhMEmgGEmH04vkrqzkgvYaDE901Yg7Y5hLZ51+zeLGz82hEImoqlabA",
+	"Py19P3+1/zk/MWayFB06GAIo8FmHKjASHu4pfaUFpRU5eBjtVMQurelfwMlURzmKwY06q+whDjLnVP9W",
+	"jbC4KEMZasHG320CK+9wNK8NF2GKGE0Wyq2YC+2BsR1O1+tZLpzSLZzuU+2qR70eLepWEUhiLr2DtKlb",
+	"PkJ1oJEL6hze6XNYTkjUrYQra9scuM0osT0VrSo+BYhVf0VlrlvHv5oJqLQboV6jADHESDATd1nditFa",
+	"6ut1nH0gkYRbWexP9fjqgy1b8umIi/yhKUkeUH+5f8FeoOVPWuhVmmUW3Ym8xEKFNz56qheEw08TsuvL",
+	"4XQL9bI5o8ftBPBr/ViGZxq17HOeN7nidEJE6pQDIkSukiGZKqVgcq4cUER4TR91V2KbXzwD9ZvIL53f",
+	"ybDSuj8LYXElXEMXwrUTRQMZ4SQR6NeTT29+Rof+G4d3lQ6XS7cvYUFIWmiICFOqm/RnQJ2rzOZPRDru",
+	"41aNdYvTB2TRmrrmMk02pKxPtf6betfbqWvoFGtJaOjAXXTyMSsy1Ku4iQvMxBXcNPy9qEao83d/fxwh",
+	"qYT3w7uIxbD0iGk9Z7VJVo9DOrcV0D9q4/GBmW/J21TBp0KBBq5U64qWYYdd5BJPKxxSuBIOlJArxcEW",
+	"Ruwbf7vFQlFpSNcopekn34dh4e5tqXpqYC9T6mgP04/IvxL51UY57llqHH0IQPHKPpLXcE8Zzfux0cog",
+	"W7niwp3K6N2TSHf78Xsmlta24iPBtBPMG8esq4oGc1S0DcXYOr6HpJimMPMEkSadCfpC5JzlWvG36Y4J",
+	"cJTmQmdLWeuC0DLU5IRfKchWEeYmGtPoXB+d6/foXA/vqv71t4ZgdSJjhU76MQNjv3su9r2ImUb72l7i",
+	"5dleAPiunIUGcIS1Pm+v8WtzvejPh3emm+VmRqN5Z8cRx52akW09UkZO+T3H1j1no+0T2eZTDIMsv2ck",
+	"3j3vayYQjiG9P6FW8WoPCNVIy20LbpTZ2KYbwxQniYrDCxJDeTnslvRsYGrROLpl1GE1+7IjhkUE4iyX",
+	"gG5IktgoKFIrUJCrOQW6BHkD/u1NRZqh9my56wT1w6Guh0JSXz58Y22IEpCe8avteU3YTAtPFm55Xh1I",
+	"kURPBHLlPscDevm12TpuvIfMmuvo8TgyrCci3KukVYS0vZR7z4n9MJR3BirtuFp8ZdN+Xhy9MqnpRK66",
+	"WTtEhAqp05umJrMisd0e5BzSLvJTEEWyjfiKvPG9ef1a75t7EJOsUYY0kv5T0lU677jv1lRWX2K/JWsq",
+	"zOlKSUcnb1qptRxWauCekLnd3YF+JM7RPblxCr0u/PCKP6uqbdNk2IACTVuF7gRhnf1oujHU+yIUrQHK",
+	"ZmrHiEiBLPQua06XzITmF524p5fjCm1N8Fz96Eqq9RceFPp5XTM9QSeJvoXdQGQSlqNcuoRk4w3B+sl3",
+	"5om6meaMGJOpFLnicA449rvuAYei+4rrPIEI9eYJVcqqbhfBzXXnFiRnEjEK+hG7GaFdu1qbW2io4bdt",
+	"7nRqk5nKZTLp/Opiu/EME5t2iuNY6C1TLk0z7wS9IzoF0d6F6D7bvAW3BMYRZRQQeWhDLeOgqmnrllq9",
+	"6aGFOyy2VbfQWacYlg0Fh2qGmyVD3l+Qt7NPZZtcMa0EFc/bpda5EQijaBtFW5doM4hUt3KLZL9qQvFA",
+	"8XZX3qS6NMItAQn3aSq3DFy53XWnjFU3RvAvPuDI3J4Q+t96taE6C6B4pnGPrhX5hVVvHtQJwXNMZyaP",
+	"udU0j1gGYxbAyIu+E170VvOF3lbt/YbV7peLPGZv3hNgefsKjw7yRR7tDYhRJ/yT8uHH7f0MbWcTj+Bb",
+	"Yrrqsga504DuIEepsz9XFilWSu+KXE0zp23N57lMtP+BUbD/ZcBLyIzLRPk0jGMlBk6u/VsKzKA0Ll5B",
+	"JBY1vwWR1lFhmpPZHibGNeJJhTi3GOEJhUfi+H0idZAjc3t0SmZLfWjPRA/3+KGqHa3asbWWr5X64qK2",
+	"ODbFxUhIliEOEZBrRXmWRk1fMQq30tQ0FzdiMO6nhs9ACptG+fns/QNS7GgojjT8cL1YVC99nywqdd3t",
+	"8RgvG7Oz2lC/Tcq7gZh/I5eaKkQJlma+RPiJTjpMsKK6+bsKnjoG9g9w7VlH4hyJcwMBO4wy22RuoxXD",
+	"vTp79tCT4E8jNx++sLAw+3QrDhoXVxMV5UWiJxrqN0Bs1gPv8ebyd97Add+B1O7rmkap8z2nzpqDRYKl",
+	"wCj4mSF9yvgadHd4mScr+uedRBFk2iz65fzjB3TJ4kWIMHpz/i9t/tf75rIkT01+S3EBC05MPxzzm/FP",
+	"oes3mMcIbjPGpfbLqFA+u1H6oWsfHFvVUA1Gy0Y9leuEdIcK7fxK7W/q5b8jUKcgUMYILXJn1PBYVPr2",
+	"fCXfwrINtXYDHd2vkrl7/vM6T64Mipx51YLG5yOuV7t77HPXEebxY8oJGVnZqEDvKCXDME/dvDEDliVb",
+	"MFDVfUR3H31qyb2uJdNIZiOZbe0ILnr0iF5dqr5n0WsqB8qGZg9SM+OmH2l2pNkt6l76ttbqlImHd+rP",
+	"Y8pKNPCMoZqRYp9WqGY7in2KCuyovD7JSvGGFqm/eGLq4wOqjj4AI+08AdqpqnR12eCop0Uo6Nv4nkpE",
+	"pvMy6rHJ0qjgPWoFz2Gu1/fb4XDP4Gr9ds57DvSvbmDkA+cye4mwd/0du0hPWN7NEKIbTGRChP7atqHV",
+	"Nb/mJtDu0oUHv/tPdTEaIxhPWTv1sXkzE2xVs+VH4z3ZXfvm0YkyytjH5ERRkqP1PpYBMcGVfdM5CKBx",
+	"d66Flo8qJ93lOFQEJJuWglBDprqFVKD2b21zQ5haFayvJdRXviUxu6F/1zcXFlSIIsz5ovMSw5HrjFxn",
+	"5DrNMsAHvGpyOL/Td1O23txjeMUu+R1LNr0k4nthJPsqcvbzRlkCo6di5GffR/BYtw6weZYJmFoEj+ZW",
+	"cZXl8v8GAAtYo7pc0gAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
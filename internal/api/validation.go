@@ -0,0 +1,306 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"journey/internal/api/spec"
+	"journey/internal/pgstore"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type tripContextKey struct{}
+
+// withTrip stores the trip a request body refers to, so that the domain rules
+// which depend on it (e.g. activity placement) can reach it.
+func withTrip(ctx context.Context, trip pgstore.Trip) context.Context {
+	return context.WithValue(ctx, tripContextKey{}, trip)
+}
+
+func tripFromContext(ctx context.Context) (pgstore.Trip, bool) {
+	trip, ok := ctx.Value(tripContextKey{}).(pgstore.Trip)
+	return trip, ok
+}
+
+// newValidator returns the validator used by the API: the struct tags from
+// the spec plus the domain rules that can't be expressed as tags.
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	v.RegisterStructValidationCtx(validateCreateTrip, spec.CreateTripRequest{})
+	v.RegisterStructValidationCtx(validateUpdateTrip, spec.UpdateTripRequest{})
+	v.RegisterStructValidationCtx(validateCreateActivity, spec.CreateActivityRequest{})
+	v.RegisterStructValidationCtx(validateUpdateActivity, spec.UpdateActivityRequest{})
+	v.RegisterStructValidationCtx(validateActivityLocation, spec.ActivityLocation{})
+	v.RegisterStructValidationCtx(validateBulkInvite, spec.BulkInviteRequest{})
+	v.RegisterStructValidationCtx(validateCreateJoinLink, spec.CreateJoinLinkRequest{})
+
+	return v
+}
+
+func validateCreateTrip(ctx context.Context, sl validator.StructLevel) {
+	body := sl.Current().Interface().(spec.CreateTripRequest)
+
+	validateTripDates(sl, body.StartsAt, body.EndsAt)
+	if body.StartsAt.Before(time.Now()) {
+		sl.ReportError(body.StartsAt, "starts_at", "StartsAt", "not_in_past", "")
+	}
+
+	owner := pgstore.NormalizeEmail(string(body.OwnerEmail))
+	seen := make(map[string]bool, len(body.EmailsToInvite))
+	for i, email := range body.EmailsToInvite {
+		normalized := pgstore.NormalizeEmail(string(email))
+		switch {
+		case normalized == owner:
+			sl.ReportError(email, fmt.Sprintf("emails_to_invite[%d]", i), "EmailsToInvite", "not_owner_email", "")
+		case seen[normalized]:
+			sl.ReportError(email, fmt.Sprintf("emails_to_invite[%d]", i), "EmailsToInvite", "unique_email", "")
+		}
+		seen[normalized] = true
+	}
+}
+
+func validateUpdateTrip(ctx context.Context, sl validator.StructLevel) {
+	body := sl.Current().Interface().(spec.UpdateTripRequest)
+
+	validateTripDates(sl, body.StartsAt, body.EndsAt)
+
+	// A trip that already started can still be updated, as long as its start
+	// isn't moved.
+	trip, ok := tripFromContext(ctx)
+	if ok && body.StartsAt.Equal(trip.StartsAt.Time) {
+		return
+	}
+
+	if body.StartsAt.Before(time.Now()) {
+		sl.ReportError(body.StartsAt, "starts_at", "StartsAt", "not_in_past", "")
+	}
+}
+
+func validateCreateActivity(ctx context.Context, sl validator.StructLevel) {
+	body := sl.Current().Interface().(spec.CreateActivityRequest)
+
+	validateActivity(ctx, sl, body)
+	if body.Recurrence != nil {
+		validateRecurrence(ctx, sl, body)
+	}
+}
+
+func validateUpdateActivity(ctx context.Context, sl validator.StructLevel) {
+	body := sl.Current().Interface().(spec.UpdateActivityRequest)
+
+	validateActivity(ctx, sl, createRequest(body))
+}
+
+// validateActivity checks that an activity ends after it starts and happens
+// during the trip stored in ctx.
+func validateActivity(ctx context.Context, sl validator.StructLevel, body spec.CreateActivityRequest) {
+	if body.EndsAt != nil && body.DurationMinutes != nil {
+		sl.ReportError(body.EndsAt, "ends_at", "EndsAt", "excluded_with", "duration_minutes")
+		return
+	}
+	if body.EndsAt != nil && body.EndsAt.Before(body.OccursAt) {
+		sl.ReportError(body.EndsAt, "ends_at", "EndsAt", "after_occurs_at", "")
+		return
+	}
+
+	trip, ok := tripFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	if body.OccursAt.Before(trip.StartsAt.Time) || body.OccursAt.After(trip.EndsAt.Time) {
+		sl.ReportError(body.OccursAt, "occurs_at", "OccursAt", "within_trip", "")
+		return
+	}
+
+	endsAt := activityEnd(body)
+	if endsAt == nil || !endsAt.After(trip.EndsAt.Time) {
+		return
+	}
+	if body.EndsAt != nil {
+		sl.ReportError(body.EndsAt, "ends_at", "EndsAt", "ends_within_trip", "")
+	} else {
+		sl.ReportError(body.DurationMinutes, "duration_minutes", "DurationMinutes", "ends_within_trip", "")
+	}
+}
+
+// validateRecurrence checks that only weekly recurrences have weekdays and
+// that a recurrence doesn't expand to more than maxOccurrences activities.
+func validateRecurrence(ctx context.Context, sl validator.StructLevel, body spec.CreateActivityRequest) {
+	rule := body.Recurrence
+	if rule.Frequency != pgstore.RecurrenceWeekly && len(rule.Weekdays) > 0 {
+		sl.ReportError(rule.Weekdays, "recurrence.weekdays", "Weekdays", "weekly_only", "")
+	}
+	if rule.Until != nil && rule.Until.Before(body.OccursAt) {
+		sl.ReportError(rule.Until, "recurrence.until", "Until", "after_occurs_at", "")
+		return
+	}
+
+	trip, ok := tripFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	if len(occurrences(trip, body)) > maxOccurrences {
+		sl.ReportError(rule, "recurrence", "Recurrence", "max_occurrences", strconv.Itoa(maxOccurrences))
+	}
+}
+
+// validateActivityLocation checks that coordinates come in pairs, since
+// either one alone doesn't point anywhere.
+func validateActivityLocation(ctx context.Context, sl validator.StructLevel) {
+	location := sl.Current().Interface().(spec.ActivityLocation)
+
+	switch {
+	case location.Latitude != nil && location.Longitude == nil:
+		sl.ReportError(location.Longitude, "longitude", "Longitude", "required_with", "latitude")
+	case location.Latitude == nil && location.Longitude != nil:
+		sl.ReportError(location.Latitude, "latitude", "Latitude", "required_with", "longitude")
+	}
+}
+
+func validateBulkInvite(ctx context.Context, sl validator.StructLevel) {
+	body := sl.Current().Interface().(spec.BulkInviteRequest)
+
+	seen := make(map[string]bool, len(body.Participants))
+	for i, participant := range body.Participants {
+		normalized := pgstore.NormalizeEmail(string(participant.Email))
+		if normalized != "" && seen[normalized] {
+			sl.ReportError(participant.Email, fmt.Sprintf("participants[%d].email", i), "Email", "unique_email", "")
+		}
+		seen[normalized] = true
+	}
+}
+
+func validateCreateJoinLink(ctx context.Context, sl validator.StructLevel) {
+	body := sl.Current().Interface().(spec.CreateJoinLinkRequest)
+
+	if body.ExpiresAt != nil && body.ExpiresAt.Before(time.Now()) {
+		sl.ReportError(body.ExpiresAt, "expires_at", "ExpiresAt", "not_in_past", "")
+	}
+}
+
+func validateTripDates(sl validator.StructLevel, startsAt, endsAt time.Time) {
+	if endsAt.Before(startsAt) {
+		sl.ReportError(endsAt, "ends_at", "EndsAt", "after_starts_at", "")
+	}
+}
+
+// validate runs every validation for body and, when it fails, returns the
+// error to be sent back to the client with one detail per invalid field.
+func (api API) validate(ctx context.Context, body any) *spec.Error {
+	err := api.validator.StructCtx(ctx, body)
+	if err == nil {
+		return nil
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return &spec.Error{Message: "invalid input: " + err.Error()}
+	}
+
+	details := make([]spec.ErrorDetail, 0, len(verrs))
+	for _, fe := range verrs {
+		details = append(details, spec.ErrorDetail{
+			Field:   fieldPath(fe),
+			Message: validationMessage(fe),
+		})
+	}
+
+	return &spec.Error{Message: "invalid input", Details: details}
+}
+
+// fieldPath is the path of the invalid field from the request body root, e.g.
+// participants[2].email.
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid e-mail"
+	case "url":
+		return "must be a valid URL"
+	case "uuid":
+		return "must be a valid UUID"
+	case "min":
+		if isNumber(fe) {
+			return "must be at least " + fe.Param()
+		}
+		return "must have at least " + fe.Param() + " " + lengthUnit(fe)
+	case "max":
+		if isNumber(fe) {
+			return "must be at most " + fe.Param()
+		}
+		return "must have at most " + fe.Param() + " " + lengthUnit(fe)
+	case "oneof":
+		return "must be one of: " + fe.Param()
+	case "required_with":
+		return "is required together with " + fe.Param()
+	case "excluded_with":
+		return "must not be given together with " + fe.Param()
+	case "iso4217":
+		return "must be a valid ISO 4217 currency code"
+	case "timezone":
+		return "must be a valid IANA time zone"
+	case "after_starts_at":
+		return "must not be before starts_at"
+	case "after_occurs_at":
+		return "must not be before occurs_at"
+	case "not_in_past":
+		return "must not be in the past"
+	case "unique_email":
+		return "is duplicated"
+	case "not_owner_email":
+		return "must not be the owner e-mail"
+	case "within_trip":
+		return "must be between the trip starts_at and ends_at"
+	case "ends_within_trip":
+		return "must not end after the trip ends_at"
+	case "weekly_only":
+		return "is only allowed with the weekly frequency"
+	case "max_occurrences":
+		return "must not repeat more than " + fe.Param() + " times"
+	default:
+		return "failed on the '" + fe.Tag() + "' rule"
+	}
+}
+
+func lengthUnit(fe validator.FieldError) string {
+	if fe.Kind() == reflect.Slice {
+		return "items"
+	}
+	return "characters"
+}
+
+// isNumber reports whether min and max were checked against the field value
+// rather than its length.
+func isNumber(fe validator.FieldError) bool {
+	switch fe.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"journey/internal/token"
+	"net/http"
+	"strings"
+)
+
+// SessionCookie is the cookie that carries the session token issued after a
+// magic-link sign in.
+const SessionCookie = "journey_session"
+
+// Caller is the authenticated user of a request.
+type Caller struct {
+	Email string
+}
+
+type callerContextKey struct{}
+
+// CallerFromContext returns the caller attached to ctx by Middleware.
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerContextKey{}).(Caller)
+	return caller, ok
+}
+
+// Middleware identifies the caller from a session token, sent either in the
+// SessionCookie or as a bearer token, or from a bearer JWT when jwt is not
+// nil. Requests without valid credentials go through anonymously; it's up to
+// each handler to require a caller.
+func Middleware(sessions token.Signer, jwt *token.JWTVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if email, ok := authenticate(r, sessions, jwt); ok {
+				r = r.WithContext(context.WithValue(r.Context(), callerContextKey{}, Caller{Email: email}))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func authenticate(r *http.Request, sessions token.Signer, jwt *token.JWTVerifier) (string, bool) {
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		// JWTs have three segments, our own session tokens only two.
+		if strings.Count(bearer, ".") == 2 {
+			if jwt == nil {
+				return "", false
+			}
+
+			email, err := jwt.Verify(bearer)
+			return email, err == nil
+		}
+
+		return verifySession(sessions, bearer)
+	}
+
+	if cookie, err := r.Cookie(SessionCookie); err == nil {
+		return verifySession(sessions, cookie.Value)
+	}
+
+	return "", false
+}
+
+func verifySession(sessions token.Signer, raw string) (string, bool) {
+	claims, err := sessions.Verify(raw)
+	if err != nil || claims.Purpose != token.PurposeSession {
+		return "", false
+	}
+	return claims.Email, true
+}
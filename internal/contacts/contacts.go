@@ -0,0 +1,171 @@
+// Package contacts reads the people to invite to a trip from the files
+// organizers usually have at hand: CSV spreadsheets and vCard exports.
+package contacts
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"journey/internal/ical"
+	"strings"
+)
+
+// Contact is a person read from an upload. Either field may be empty when the
+// row lacks it, so that callers can report the row instead of dropping it.
+type Contact struct {
+	Name  string
+	Email string
+}
+
+// ErrNoEmailColumn is returned by ParseCSV when the header has no email
+// column.
+var ErrNoEmailColumn = errors.New("contacts: csv header has no email column")
+
+// ParseCSV reads contacts from a CSV file whose header has an email column
+// and, optionally, a name column. Other columns are ignored. Files separated
+// by semicolons, as exported by spreadsheets in some locales, are accepted too.
+func ParseCSV(r io.Reader) ([]Contact, error) {
+	br := bufio.NewReader(r)
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if first, _ := br.Peek(br.Size()); isSemicolonSeparated(first) {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrNoEmailColumn
+		}
+		return nil, fmt.Errorf("contacts: invalid csv: %w", err)
+	}
+
+	nameCol, emailCol := -1, -1
+	for i, h := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))) {
+		case "name":
+			nameCol = i
+		case "email", "e-mail":
+			emailCol = i
+		}
+	}
+
+	if emailCol == -1 {
+		return nil, ErrNoEmailColumn
+	}
+
+	var contacts []Contact
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("contacts: invalid csv: %w", err)
+		}
+
+		contacts = append(contacts, Contact{
+			Name:  column(record, nameCol),
+			Email: column(record, emailCol),
+		})
+	}
+
+	return contacts, nil
+}
+
+func isSemicolonSeparated(data []byte) bool {
+	line, _, _ := strings.Cut(string(data), "\n")
+	return strings.Count(line, ";") > strings.Count(line, ",")
+}
+
+func column(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// ParseVCard reads contacts from a vCard file (.vcf) with one or more cards.
+// The name comes from FN, or from N when FN is missing, and the e-mail is the
+// first EMAIL of the card.
+func ParseVCard(r io.Reader) ([]Contact, error) {
+	lines, err := ical.Unfold(r)
+	if err != nil {
+		return nil, fmt.Errorf("contacts: invalid vcard: %w", err)
+	}
+
+	var (
+		contacts []Contact
+		card     *Contact
+		n        string
+	)
+	for _, line := range lines {
+		property, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		name, _, _ := strings.Cut(property, ";")
+		if _, after, grouped := strings.Cut(name, "."); grouped {
+			name = after
+		}
+
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VCARD") {
+				card, n = &Contact{}, ""
+			}
+		case "END":
+			if card != nil && strings.EqualFold(value, "VCARD") {
+				if card.Name == "" {
+					card.Name = structuredName(n)
+				}
+				contacts = append(contacts, *card)
+				card = nil
+			}
+		case "FN":
+			if card != nil {
+				card.Name = strings.TrimSpace(unescape(value))
+			}
+		case "N":
+			if card != nil {
+				n = value
+			}
+		case "EMAIL":
+			if card != nil && card.Email == "" {
+				card.Email = strings.TrimSpace(unescape(value))
+			}
+		}
+	}
+
+	if card != nil {
+		return nil, errors.New("contacts: invalid vcard: missing END:VCARD")
+	}
+
+	return contacts, nil
+}
+
+// structuredName turns an N value (family;given;additional;prefixes;suffixes)
+// into "given family".
+func structuredName(n string) string {
+	parts := strings.Split(n, ";")
+	var names []string
+	for _, i := range []int{1, 0} {
+		if i < len(parts) {
+			if part := strings.TrimSpace(unescape(parts[i])); part != "" {
+				names = append(names, part)
+			}
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// unescape reads a vCard TEXT value, which escapes characters like iCalendar
+// does, on a single line.
+func unescape(value string) string {
+	return strings.ReplaceAll(ical.Unescape(value), "\n", " ")
+}
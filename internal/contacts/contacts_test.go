@@ -0,0 +1,161 @@
+package contacts
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Contact
+		wantErr error
+	}{
+		{
+			name:  "comma separated",
+			input: "name,email\nAna Silva,ana@example.com\nBruno,bruno@example.com\n",
+			want: []Contact{
+				{Name: "Ana Silva", Email: "ana@example.com"},
+				{Name: "Bruno", Email: "bruno@example.com"},
+			},
+		},
+		{
+			name:  "semicolon separated",
+			input: "Name;E-mail\r\n\"Silva, Ana\";ana@example.com\r\n",
+			want:  []Contact{{Name: "Silva, Ana", Email: "ana@example.com"}},
+		},
+		{
+			name:  "commas in the values of a semicolon separated file",
+			input: "email;name\nana@example.com;Ana, from work\n",
+			want:  []Contact{{Name: "Ana, from work", Email: "ana@example.com"}},
+		},
+		{
+			name:  "byte order mark",
+			input: "\xef\xbb\xbfEmail,Name\nana@example.com,Ana\n",
+			want:  []Contact{{Name: "Ana", Email: "ana@example.com"}},
+		},
+		{
+			name:  "byte order mark and semicolons",
+			input: "\xef\xbb\xbfemail;name\nana@example.com;Ana\n",
+			want:  []Contact{{Name: "Ana", Email: "ana@example.com"}},
+		},
+		{
+			name:  "other columns and short rows",
+			input: "phone, email , name\n555, ana@example.com , Ana \n556\n",
+			want: []Contact{
+				{Name: "Ana", Email: "ana@example.com"},
+				{},
+			},
+		},
+		{
+			name:  "no name column",
+			input: "email\nana@example.com\n",
+			want:  []Contact{{Email: "ana@example.com"}},
+		},
+		{
+			name:  "header only",
+			input: "name,email\n",
+		},
+		{
+			name:    "no email column",
+			input:   "name,phone\nAna,555\n",
+			wantErr: ErrNoEmailColumn,
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: ErrNoEmailColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCSV(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCSV() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCSV() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCSVRejectsInvalidFiles(t *testing.T) {
+	if _, err := ParseCSV(strings.NewReader("name,email\n\"Ana,ana@example.com\n")); err == nil {
+		t.Error("ParseCSV() accepted an unterminated quote")
+	}
+}
+
+func TestParseVCard(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Contact
+	}{
+		{
+			name: "several cards",
+			input: "BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Ana Silva\r\nEMAIL:ana@example.com\r\nEND:VCARD\r\n" +
+				"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Bruno\r\nEMAIL:bruno@example.com\r\nEND:VCARD\r\n",
+			want: []Contact{
+				{Name: "Ana Silva", Email: "ana@example.com"},
+				{Name: "Bruno", Email: "bruno@example.com"},
+			},
+		},
+		{
+			name:  "name from N when FN is missing",
+			input: "BEGIN:VCARD\nN:Silva;Ana;Maria;Dr.;\nEMAIL:ana@example.com\nEND:VCARD\n",
+			want:  []Contact{{Name: "Ana Silva", Email: "ana@example.com"}},
+		},
+		{
+			name:  "FN wins over N",
+			input: "BEGIN:VCARD\nN:Silva;Ana;;;\nFN:Aninha\nEND:VCARD\n",
+			want:  []Contact{{Name: "Aninha"}},
+		},
+		{
+			name:  "N with only a family name",
+			input: "BEGIN:VCARD\nN:Silva;;;;\nEND:VCARD\n",
+			want:  []Contact{{Name: "Silva"}},
+		},
+		{
+			name:  "first e-mail, with parameters and groups",
+			input: "BEGIN:VCARD\nFN:Ana\nitem1.EMAIL;TYPE=work:ana@work.example.com\nEMAIL;TYPE=home:ana@example.com\nEND:VCARD\n",
+			want:  []Contact{{Name: "Ana", Email: "ana@work.example.com"}},
+		},
+		{
+			name:  "escaped values",
+			input: "BEGIN:VCARD\nFN:Silva\\, Ana\\nfrom work\nEND:VCARD\n",
+			want:  []Contact{{Name: "Silva, Ana from work"}},
+		},
+		{
+			name:  "properties outside cards",
+			input: "FN:Nobody\nEMAIL:nobody@example.com\nBEGIN:VCARD\nEMAIL:ana@example.com\nEND:VCARD\n",
+			want:  []Contact{{Email: "ana@example.com"}},
+		},
+		{
+			name:  "empty",
+			input: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVCard(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseVCard() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVCardRejectsUnterminatedCards(t *testing.T) {
+	if _, err := ParseVCard(strings.NewReader("BEGIN:VCARD\nFN:Ana\n")); err == nil {
+		t.Error("ParseVCard() accepted a card without END:VCARD")
+	}
+}
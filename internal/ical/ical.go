@@ -0,0 +1,272 @@
+// Package ical reads the events of iCalendar files (.ics), such as the
+// bookings calendars sent by travel agencies or exported from calendar apps.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a VEVENT read from a calendar. Fields the event lacks, or whose
+// value can't be read, are left zero so that callers can report the event
+// instead of dropping it.
+type Event struct {
+	UID         string
+	Summary     string
+	Location    string
+	Description string
+
+	// Start and End are when the event happens. End is zero when the event
+	// has neither DTEND nor DURATION.
+	Start time.Time
+	End   time.Time
+
+	// Timezone is the IANA name of the TZID of DTSTART, when there's one and
+	// it's known. Dates and times without a time zone are read in the
+	// location given to Parse.
+	Timezone string
+
+	// AllDay is true when DTSTART is a date rather than a date and time.
+	AllDay bool
+}
+
+// ErrNotCalendar is returned by Parse when the file isn't an iCalendar.
+var ErrNotCalendar = errors.New("ical: not an icalendar file")
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405"
+)
+
+// Parse reads the events of an iCalendar file. Components nested in an event,
+// like its alarms, are ignored. Dates, and times without a time zone, are
+// read in loc.
+func Parse(r io.Reader, loc *time.Location) ([]Event, error) {
+	lines, err := Unfold(r)
+	if err != nil {
+		return nil, fmt.Errorf("ical: invalid icalendar: %w", err)
+	}
+
+	var (
+		events   []Event
+		event    *Event
+		found    bool
+		nested   int
+		duration string
+	)
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		name, params, value, ok := property(line)
+		if !ok {
+			continue
+		}
+
+		if !found {
+			if name != "BEGIN" || !strings.EqualFold(value, "VCALENDAR") {
+				return nil, ErrNotCalendar
+			}
+			found = true
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && event == nil:
+			if strings.EqualFold(value, "VEVENT") {
+				event, nested, duration = &Event{}, 0, ""
+			}
+		case name == "BEGIN":
+			nested++
+		case name == "END" && event != nil && nested > 0:
+			nested--
+		case name == "END" && event != nil:
+			if event.End.IsZero() && duration != "" && !event.Start.IsZero() {
+				if d, err := parseDuration(duration); err == nil {
+					event.End = event.Start.Add(d)
+				}
+			}
+			events = append(events, *event)
+			event = nil
+		case event == nil || nested > 0:
+			continue
+		case name == "UID":
+			event.UID = strings.TrimSpace(value)
+		case name == "SUMMARY":
+			event.Summary = strings.TrimSpace(Unescape(value))
+		case name == "LOCATION":
+			event.Location = strings.TrimSpace(Unescape(value))
+		case name == "DESCRIPTION":
+			event.Description = strings.TrimSpace(Unescape(value))
+		case name == "DTSTART":
+			event.Start, event.Timezone, event.AllDay = parseTime(params, value, loc)
+		case name == "DTEND":
+			event.End, _, _ = parseTime(params, value, loc)
+		case name == "DURATION":
+			duration = value
+		}
+	}
+
+	if !found {
+		return nil, ErrNotCalendar
+	}
+	if event != nil {
+		return nil, errors.New("ical: invalid icalendar: missing END:VEVENT")
+	}
+
+	return events, nil
+}
+
+// Unfold splits r into content lines, joining the lines folded with a leading
+// space or tab. iCalendar (RFC 5545) and vCard (RFC 6350) fold lines alike.
+func Unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimPrefix(line, "\ufeff"))
+	}
+
+	return lines, scanner.Err()
+}
+
+// property splits a content line, name;param=value;...:value, into its upper
+// cased name, its parameters keyed by upper cased name, and its value.
+// Parameter values may be quoted, and then hold colons.
+func property(line string) (string, map[string]string, string, bool) {
+	quoted := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ':' && !quoted:
+			parts := strings.Split(line[:i], ";")
+			params := make(map[string]string, len(parts)-1)
+			for _, part := range parts[1:] {
+				key, value, _ := strings.Cut(part, "=")
+				params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+			}
+			return strings.ToUpper(parts[0]), params, line[i+1:], true
+		}
+	}
+	return "", nil, "", false
+}
+
+// parseTime reads a DATE or DATE-TIME value, returning the zero time when it
+// can't. Values in UTC end with Z, others are read in the location of their
+// TZID parameter when it's known, or in loc. The IANA name of the TZID is
+// returned when it was used.
+func parseTime(params map[string]string, value string, loc *time.Location) (time.Time, string, bool) {
+	value = strings.TrimSpace(value)
+
+	if params["VALUE"] == "DATE" || len(value) == len(dateFormat) {
+		t, err := time.ParseInLocation(dateFormat, value, loc)
+		if err != nil {
+			return time.Time{}, "", false
+		}
+		return t, "", true
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeFormat, strings.TrimSuffix(value, "Z"))
+		if err != nil {
+			return time.Time{}, "", false
+		}
+		return t, "", false
+	}
+
+	var timezone string
+	if tzid := params["TZID"]; tzid != "" {
+		// Some apps prefix the name with a slash, and Outlook uses Windows
+		// names which can't be loaded: those fall back to loc.
+		if tz, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			loc, timezone = tz, tz.String()
+		}
+	}
+
+	t, err := time.ParseInLocation(dateTimeFormat, value, loc)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return t, timezone, false
+}
+
+// parseDuration reads a DURATION value, e.g. PT1H30M, P1D or P2W.
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.TrimSpace(value)
+	sign := time.Duration(1)
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		s, sign = rest, -1
+	}
+	s = strings.TrimPrefix(s, "+")
+
+	rest, ok := strings.CutPrefix(s, "P")
+	if !ok || rest == "" {
+		return 0, fmt.Errorf("ical: invalid duration %q", value)
+	}
+
+	var (
+		total    time.Duration
+		inTime   bool
+		timeRead bool
+		digits   string
+	)
+	for _, r := range rest {
+		switch {
+		case r >= '0' && r <= '9':
+			digits += string(r)
+			continue
+		case r == 'T' && !inTime && digits == "":
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			return 0, fmt.Errorf("ical: invalid duration %q", value)
+		}
+		digits = ""
+
+		var unit time.Duration
+		switch {
+		case r == 'W' && !inTime:
+			unit = 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			unit = 24 * time.Hour
+		case r == 'H' && inTime:
+			unit = time.Hour
+		case r == 'M' && inTime:
+			unit = time.Minute
+		case r == 'S' && inTime:
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("ical: invalid duration %q", value)
+		}
+		total += time.Duration(n) * unit
+		timeRead = timeRead || inTime
+	}
+
+	// A T must be followed by hours, minutes or seconds.
+	if digits != "" || inTime && !timeRead {
+		return 0, fmt.Errorf("ical: invalid duration %q", value)
+	}
+	return sign * total, nil
+}
+
+// Unescape replaces the escaped commas, semicolons, newlines and backslashes
+// of a TEXT value.
+func Unescape(value string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, "\n", `\N`, "\n", `\\`, `\`).Replace(value)
+}
@@ -0,0 +1,315 @@
+package ical
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnfold(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "CRLF line breaks",
+			input: "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+			want:  []string{"BEGIN:VCALENDAR", "END:VCALENDAR"},
+		},
+		{
+			name:  "folded with a space",
+			input: "SUMMARY:Dinner at\r\n  the harbour\r\nEND:VEVENT\r\n",
+			want:  []string{"SUMMARY:Dinner at the harbour", "END:VEVENT"},
+		},
+		{
+			name:  "folded with a tab, several times",
+			input: "DESCRIPTION:a\n\tb\n\tc\n",
+			want:  []string{"DESCRIPTION:abc"},
+		},
+		{
+			name:  "byte order mark",
+			input: "\xef\xbb\xbfBEGIN:VCALENDAR\n",
+			want:  []string{"BEGIN:VCALENDAR"},
+		},
+		{
+			name:  "leading space on the first line",
+			input: " BEGIN:VCALENDAR\n",
+			want:  []string{" BEGIN:VCALENDAR"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Unfold(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unfold() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProperty(t *testing.T) {
+	tests := []struct {
+		line   string
+		name   string
+		params map[string]string
+		value  string
+		ok     bool
+	}{
+		{
+			line:   "SUMMARY:Dinner",
+			name:   "SUMMARY",
+			params: map[string]string{},
+			value:  "Dinner",
+			ok:     true,
+		},
+		{
+			line:   "dtstart;tzid=Europe/Lisbon:20240611T200000",
+			name:   "DTSTART",
+			params: map[string]string{"TZID": "Europe/Lisbon"},
+			value:  "20240611T200000",
+			ok:     true,
+		},
+		{
+			line:   `ATTENDEE;CN="Doe: Jane";ROLE=CHAIR:mailto:jane@example.com`,
+			name:   "ATTENDEE",
+			params: map[string]string{"CN": "Doe: Jane", "ROLE": "CHAIR"},
+			value:  "mailto:jane@example.com",
+			ok:     true,
+		},
+		{
+			line:   "LOCATION:Rua Augusta: 24",
+			name:   "LOCATION",
+			params: map[string]string{},
+			value:  "Rua Augusta: 24",
+			ok:     true,
+		},
+		{
+			line: "not a property",
+		},
+		{
+			line: `X-NAME;P="unterminated:value`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			name, params, value, ok := property(tt.line)
+			if ok != tt.ok {
+				t.Fatalf("property() ok = %v, want %v", ok, tt.ok)
+			}
+			if name != tt.name || value != tt.value || !reflect.DeepEqual(params, tt.params) {
+				t.Errorf("property() = %q, %v, %q, want %q, %v, %q", name, params, value, tt.name, tt.params, tt.value)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	lisbon, err := time.LoadLocation("Europe/Lisbon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		params   map[string]string
+		value    string
+		want     time.Time
+		timezone string
+		allDay   bool
+	}{
+		{
+			name:  "UTC",
+			value: "20240611T200000Z",
+			want:  time.Date(2024, time.June, 11, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "known TZID",
+			params:   map[string]string{"TZID": "Asia/Tokyo"},
+			value:    "20240611T200000",
+			want:     time.Date(2024, time.June, 11, 20, 0, 0, 0, tokyo),
+			timezone: "Asia/Tokyo",
+		},
+		{
+			name:     "TZID with a leading slash",
+			params:   map[string]string{"TZID": "/Asia/Tokyo"},
+			value:    "20240611T200000",
+			want:     time.Date(2024, time.June, 11, 20, 0, 0, 0, tokyo),
+			timezone: "Asia/Tokyo",
+		},
+		{
+			name:   "unknown TZID falls back to the given location",
+			params: map[string]string{"TZID": "W. Europe Standard Time"},
+			value:  "20240611T200000",
+			want:   time.Date(2024, time.June, 11, 20, 0, 0, 0, lisbon),
+		},
+		{
+			name:  "floating time",
+			value: "20240611T200000",
+			want:  time.Date(2024, time.June, 11, 20, 0, 0, 0, lisbon),
+		},
+		{
+			name:   "date",
+			params: map[string]string{"VALUE": "DATE"},
+			value:  "20240611",
+			want:   time.Date(2024, time.June, 11, 0, 0, 0, 0, lisbon),
+			allDay: true,
+		},
+		{
+			name:   "date without VALUE",
+			value:  "20240611",
+			want:   time.Date(2024, time.June, 11, 0, 0, 0, 0, lisbon),
+			allDay: true,
+		},
+		{
+			name:  "invalid",
+			value: "tomorrow",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, timezone, allDay := parseTime(tt.params, tt.value, lisbon)
+			if !got.Equal(tt.want) || timezone != tt.timezone || allDay != tt.allDay {
+				t.Errorf("parseTime() = %v, %q, %v, want %v, %q, %v", got, timezone, allDay, tt.want, tt.timezone, tt.allDay)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "PT1H30M", want: 90 * time.Minute},
+		{value: "PT45S", want: 45 * time.Second},
+		{value: "P1D", want: 24 * time.Hour},
+		{value: "P2W", want: 14 * 24 * time.Hour},
+		{value: "P1DT2H", want: 26 * time.Hour},
+		{value: "+PT15M", want: 15 * time.Minute},
+		{value: "-PT15M", want: -15 * time.Minute},
+		{value: " PT1H ", want: time.Hour},
+		{value: "", wantErr: true},
+		{value: "P", wantErr: true},
+		{value: "1H", wantErr: true},
+		{value: "PT", wantErr: true},
+		{value: "P1DT", wantErr: true},
+		{value: "P1H", wantErr: true},
+		{value: "PT1D", wantErr: true},
+		{value: "PT1", wantErr: true},
+		{value: "PTH", wantErr: true},
+		{value: "P1X", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDuration(%q) error = %v, want error %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseDuration(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	lisbon, err := time.LoadLocation("Europe/Lisbon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:dinner@example.com",
+		"SUMMARY:Dinner\\, at the harbour",
+		"LOCATION:Cais do Sodré",
+		"DTSTART;TZID=Europe/Lisbon:20240611T200000",
+		"DURATION:PT2H",
+		"BEGIN:VALARM",
+		"DESCRIPTION:Reminder",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:museum@example.com",
+		"SUMMARY:Museum",
+		"DTSTART;VALUE=DATE:20240612",
+		"DTEND;VALUE=DATE:20240613",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := Parse(strings.NewReader(input), lisbon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Event{
+		{
+			UID:      "dinner@example.com",
+			Summary:  "Dinner, at the harbour",
+			Location: "Cais do Sodré",
+			Start:    time.Date(2024, time.June, 11, 20, 0, 0, 0, lisbon),
+			End:      time.Date(2024, time.June, 11, 22, 0, 0, 0, lisbon),
+			Timezone: "Europe/Lisbon",
+		},
+		{
+			UID:     "museum@example.com",
+			Summary: "Museum",
+			Start:   time.Date(2024, time.June, 12, 0, 0, 0, 0, lisbon),
+			End:     time.Date(2024, time.June, 13, 0, 0, 0, 0, lisbon),
+			AllDay:  true,
+		},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Parse() returned %d events, want %d", len(events), len(want))
+	}
+	for i := range want {
+		got := events[i]
+		if got.UID != want[i].UID || got.Summary != want[i].Summary || got.Location != want[i].Location ||
+			!got.Start.Equal(want[i].Start) || !got.End.Equal(want[i].End) ||
+			got.Timezone != want[i].Timezone || got.AllDay != want[i].AllDay {
+			t.Errorf("event %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "empty", input: "", wantErr: ErrNotCalendar},
+		{name: "vcard", input: "BEGIN:VCARD\r\nEND:VCARD\r\n", wantErr: ErrNotCalendar},
+		{name: "unterminated event", input: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input), time.UTC)
+			if err == nil {
+				t.Fatal("Parse() returned no error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
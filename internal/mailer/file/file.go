@@ -0,0 +1,42 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"journey/internal/mailer"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// File writes every message sent through it as an .eml file, which can be
+// opened by any mail client.
+type File struct {
+	dir string
+}
+
+// NewFile returns a sender that writes to dir, creating it if needed.
+func NewFile(dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("file: failed to create directory %s: %w", dir, err)
+	}
+
+	return &File{dir}, nil
+}
+
+func (f *File) Send(_ context.Context, m mailer.Message) error {
+	msg, err := m.Msg()
+	if err != nil {
+		return fmt.Errorf("file: failed to build message: %w", err)
+	}
+
+	// The timestamp prefix keeps the files sorted by the time they were sent.
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), uuid.NewString())
+	if err := msg.WriteToFile(filepath.Join(f.dir, name)); err != nil {
+		return fmt.Errorf("file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"journey/internal/mailer/templates"
+	"journey/internal/pgstore"
+	"journey/internal/token"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wneessen/go-mail"
+)
+
+// Message is a rendered e-mail, ready to be handed to a Sender.
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Msg builds a multipart message, with the text body as the alternative of
+// the HTML one.
+func (m Message) Msg() (*mail.Msg, error) {
+	msg := mail.NewMsg()
+	if err := msg.From(m.From); err != nil {
+		return nil, fmt.Errorf("failed to set From: %w", err)
+	}
+
+	if err := msg.To(m.To); err != nil {
+		return nil, fmt.Errorf("failed to set To: %w", err)
+	}
+
+	msg.Subject(m.Subject)
+	msg.SetDate()
+	msg.SetMessageID()
+	msg.SetBodyString(mail.TypeTextPlain, m.Text)
+	msg.AddAlternativeString(mail.TypeTextHTML, m.HTML)
+
+	return msg, nil
+}
+
+// Sender delivers rendered messages. Each backend (smtp, file, memory)
+// implements it.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// How long the links sent by e-mail stay valid.
+const (
+	ConfirmTripTokenTTL        = 7 * 24 * time.Hour
+	ConfirmParticipantTokenTTL = 30 * 24 * time.Hour
+	LoginTokenTTL              = 15 * time.Minute
+)
+
+type store interface {
+	GetTrip(context.Context, uuid.UUID) (pgstore.Trip, error)
+	GetParticipant(context.Context, uuid.UUID) (pgstore.Participant, error)
+}
+
+// Mailer renders the e-mails sent by the application and hands them to a
+// Sender.
+type Mailer struct {
+	store         store
+	renderer      *templates.Renderer
+	sender        Sender
+	tokens        token.Signer
+	from          string
+	publicBaseURL string
+}
+
+// NewMailer returns a Mailer that sends through sender. Links in the e-mails
+// are built on top of publicBaseURL and carry tokens signed by tokens.
+func NewMailer(
+	pool *pgxpool.Pool,
+	renderer *templates.Renderer,
+	sender Sender,
+	tokens token.Signer,
+	from string,
+	publicBaseURL string,
+) *Mailer {
+	return &Mailer{
+		store:         pgstore.New(pool),
+		renderer:      renderer,
+		sender:        sender,
+		tokens:        tokens,
+		from:          from,
+		publicBaseURL: strings.TrimSuffix(publicBaseURL, "/"),
+	}
+}
+
+func (m *Mailer) SendConfirmTripEmailToTripOwner(tripID uuid.UUID) error {
+	ctx := context.Background()
+
+	trip, err := m.store.GetTrip(ctx, tripID)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to get trip for SendConfirmTripEmailToTripOwner: %w", err)
+	}
+
+	confirmURL, err := m.confirmURL(token.PurposeConfirmTrip, trip.ID, ConfirmTripTokenTTL)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to issue token for SendConfirmTripEmailToTripOwner: %w", err)
+	}
+
+	email, err := m.renderer.Render(
+		trip.Locale,
+		pgstore.EmailKindConfirmTrip,
+		templateData(trip, pgstore.Participant{}),
+		confirmURL,
+	)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to render email SendConfirmTripEmailToTripOwner: %w", err)
+	}
+
+	if err := m.send(ctx, trip.OwnerEmail, email); err != nil {
+		return fmt.Errorf("mailer: failed to send email SendConfirmTripEmailToTripOwner: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Mailer) SendInviteEmailToParticipant(tripID, participantID uuid.UUID) error {
+	ctx := context.Background()
+
+	trip, err := m.store.GetTrip(ctx, tripID)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to get trip for SendInviteEmailToParticipant: %w", err)
+	}
+
+	participant, err := m.store.GetParticipant(ctx, participantID)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to get participant for SendInviteEmailToParticipant: %w", err)
+	}
+
+	// The invitee may have been removed, or have declined, while the e-mail
+	// waited in the outbox.
+	if participant.Status != pgstore.ParticipantStatusInvited {
+		return nil
+	}
+
+	confirmURL, err := m.confirmURL(token.PurposeConfirmParticipant, participant.ID, ConfirmParticipantTokenTTL)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to issue token for SendInviteEmailToParticipant: %w", err)
+	}
+
+	email, err := m.renderer.Render(
+		trip.Locale,
+		pgstore.EmailKindInviteParticipant,
+		templateData(trip, participant),
+		confirmURL,
+	)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to render email SendInviteEmailToParticipant: %w", err)
+	}
+
+	if err := m.send(ctx, participant.Email, email); err != nil {
+		return fmt.Errorf("mailer: failed to send email SendInviteEmailToParticipant: %w", err)
+	}
+
+	return nil
+}
+
+// SendWaitlistPromotedEmailToParticipant tells a participant that a seat was
+// freed and they moved from the waitlist to the confirmed participants.
+func (m *Mailer) SendWaitlistPromotedEmailToParticipant(tripID, participantID uuid.UUID) error {
+	ctx := context.Background()
+
+	trip, err := m.store.GetTrip(ctx, tripID)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to get trip for SendWaitlistPromotedEmailToParticipant: %w", err)
+	}
+
+	participant, err := m.store.GetParticipant(ctx, participantID)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to get participant for SendWaitlistPromotedEmailToParticipant: %w", err)
+	}
+
+	// The participant may have declined, or been removed, in the meantime.
+	if participant.Status != pgstore.ParticipantStatusConfirmed {
+		return nil
+	}
+
+	email, err := m.renderer.Render(
+		trip.Locale,
+		pgstore.EmailKindWaitlistPromoted,
+		templateData(trip, participant),
+		"",
+	)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to render email SendWaitlistPromotedEmailToParticipant: %w", err)
+	}
+
+	if err := m.send(ctx, participant.Email, email); err != nil {
+		return fmt.Errorf("mailer: failed to send email SendWaitlistPromotedEmailToParticipant: %w", err)
+	}
+
+	return nil
+}
+
+// SendLoginEmail sends a sign-in link to email. The e-mail is rendered in
+// the default locale, as there is no trip to take it from.
+func (m *Mailer) SendLoginEmail(email string) error {
+	t, err := m.tokens.IssueForEmail(token.PurposeLogin, email, LoginTokenTTL)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to issue token for SendLoginEmail: %w", err)
+	}
+
+	rendered, err := m.renderer.Render(
+		templates.DefaultLocale,
+		pgstore.EmailKindLogin,
+		templates.Data{},
+		m.link("/auth/session", t),
+	)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to render email SendLoginEmail: %w", err)
+	}
+
+	if err := m.send(context.Background(), email, rendered); err != nil {
+		return fmt.Errorf("mailer: failed to send email SendLoginEmail: %w", err)
+	}
+
+	return nil
+}
+
+// confirmURL returns the link of the confirmation endpoint with a new token
+// for subject.
+func (m *Mailer) confirmURL(purpose string, subject uuid.UUID, ttl time.Duration) (string, error) {
+	t, err := m.tokens.Issue(purpose, subject, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	return m.link("/confirm", t), nil
+}
+
+func (m *Mailer) link(path, t string) string {
+	return m.publicBaseURL + path + "?" + url.Values{"token": {t}}.Encode()
+}
+
+func (m *Mailer) send(ctx context.Context, to string, email templates.Email) error {
+	return m.sender.Send(ctx, Message{
+		From:    m.from,
+		To:      to,
+		Subject: email.Subject,
+		HTML:    email.HTML,
+		Text:    email.Text,
+	})
+}
+
+func templateData(trip pgstore.Trip, participant pgstore.Participant) templates.Data {
+	loc := trip.Location()
+	return templates.Data{
+		OwnerName:       trip.OwnerName,
+		ParticipantName: participant.Name.String,
+		Destination:     trip.Destination,
+		StartsAt:        trip.StartsAt.Time.In(loc),
+		EndsAt:          trip.EndsAt.Time.In(loc),
+	}
+}
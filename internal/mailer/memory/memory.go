@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"journey/internal/mailer"
+	"sync"
+)
+
+// Memory keeps every message sent through it, so they can be inspected by
+// tests instead of being delivered.
+type Memory struct {
+	mu       sync.Mutex
+	messages []mailer.Message
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Send(_ context.Context, msg mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first.
+func (m *Memory) Messages() []mailer.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages := make([]mailer.Message, len(m.messages))
+	copy(messages, m.messages)
+	return messages
+}
+
+// Reset forgets every message sent so far.
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = nil
+}
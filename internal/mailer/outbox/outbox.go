@@ -0,0 +1,152 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"journey/internal/pgstore"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	// pollInterval is how often the outbox is checked for pending e-mails.
+	pollInterval = 5 * time.Second
+	// batchSize is the maximum number of e-mails claimed on each poll.
+	batchSize = 10
+	// lease is how long a claimed e-mail is hidden from other dispatchers. If
+	// the process dies mid-send, the e-mail is picked up again after it.
+	lease = 5 * time.Minute
+
+	// maxAttempts is how many times an e-mail is tried before it is moved to
+	// the dead state.
+	maxAttempts = 8
+	baseBackoff = 30 * time.Second
+	maxBackoff  = time.Hour
+)
+
+type store interface {
+	ClaimPendingEmails(ctx context.Context, arg pgstore.ClaimPendingEmailsParams) ([]pgstore.EmailOutbox, error)
+	MarkEmailSent(ctx context.Context, id uuid.UUID) error
+	MarkEmailFailed(ctx context.Context, arg pgstore.MarkEmailFailedParams) error
+}
+
+type mailer interface {
+	SendConfirmTripEmailToTripOwner(tripID uuid.UUID) error
+	SendInviteEmailToParticipant(tripID, participantID uuid.UUID) error
+	SendLoginEmail(email string) error
+	SendWaitlistPromotedEmailToParticipant(tripID, participantID uuid.UUID) error
+}
+
+// Dispatcher sends the e-mails stored in the email_outbox table, retrying
+// failed ones with exponential backoff.
+type Dispatcher struct {
+	store  store
+	mailer mailer
+	logger *zap.Logger
+}
+
+func NewDispatcher(pool *pgxpool.Pool, logger *zap.Logger, mailer mailer) Dispatcher {
+	return Dispatcher{pgstore.New(pool), mailer, logger.Named("outbox")}
+}
+
+// Run dispatches pending e-mails until ctx is done.
+func (d Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d Dispatcher) dispatch(ctx context.Context) {
+	emails, err := d.store.ClaimPendingEmails(ctx, pgstore.ClaimPendingEmailsParams{
+		Lease:     pgtype.Interval{Valid: true, Microseconds: lease.Microseconds()},
+		MaxEmails: batchSize,
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			d.logger.Error("failed to claim pending emails", zap.Error(err))
+		}
+		return
+	}
+
+	// The outcome of an e-mail already sent must be recorded even if we are
+	// shutting down, otherwise it would be sent again.
+	ctx = context.WithoutCancel(ctx)
+
+	for _, email := range emails {
+		if err := d.send(email); err != nil {
+			d.fail(ctx, email, err)
+			continue
+		}
+
+		if err := d.store.MarkEmailSent(ctx, email.ID); err != nil {
+			d.logger.Error("failed to mark email as sent", zap.Error(err), zap.String("email_id", email.ID.String()))
+		}
+	}
+}
+
+func (d Dispatcher) send(email pgstore.EmailOutbox) error {
+	switch email.Kind {
+	case pgstore.EmailKindConfirmTrip:
+		return d.mailer.SendConfirmTripEmailToTripOwner(email.TripID.Bytes)
+	case pgstore.EmailKindInviteParticipant:
+		return d.mailer.SendInviteEmailToParticipant(email.TripID.Bytes, email.ParticipantID.Bytes)
+	case pgstore.EmailKindLogin:
+		return d.mailer.SendLoginEmail(email.Recipient.String)
+	case pgstore.EmailKindWaitlistPromoted:
+		return d.mailer.SendWaitlistPromotedEmailToParticipant(email.TripID.Bytes, email.ParticipantID.Bytes)
+	default:
+		return fmt.Errorf("outbox: unknown email kind %q", email.Kind)
+	}
+}
+
+func (d Dispatcher) fail(ctx context.Context, email pgstore.EmailOutbox, sendErr error) {
+	attempts := email.Attempts + 1
+
+	status := pgstore.EmailStatusPending
+	if attempts >= maxAttempts {
+		status = pgstore.EmailStatusDead
+	}
+
+	d.logger.Warn(
+		"failed to send email",
+		zap.Error(sendErr),
+		zap.String("email_id", email.ID.String()),
+		zap.Int32("attempts", attempts),
+		zap.String("status", status),
+	)
+
+	if err := d.store.MarkEmailFailed(ctx, pgstore.MarkEmailFailedParams{
+		Status:        status,
+		LastError:     pgtype.Text{Valid: true, String: sendErr.Error()},
+		NextAttemptAt: pgtype.Timestamptz{Valid: true, Time: time.Now().Add(backoff(attempts))},
+		ID:            email.ID,
+	}); err != nil {
+		d.logger.Error("failed to mark email as failed", zap.Error(err), zap.String("email_id", email.ID.String()))
+	}
+}
+
+// backoff returns how long to wait before the next try of an e-mail that
+// already failed the given number of attempts.
+func backoff(attempts int32) time.Duration {
+	delay := baseBackoff
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,252 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	journeymailer "journey/internal/mailer"
+	"journey/internal/mailer/memory"
+	"journey/internal/mailer/templates"
+	"journey/internal/pgstore"
+	"journey/internal/token"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// fakeStore keeps the outbox in memory, claiming and marking e-mails the way
+// the queries do, with now as the database clock.
+type fakeStore struct {
+	now    time.Time
+	emails []pgstore.EmailOutbox
+}
+
+func (s *fakeStore) ClaimPendingEmails(_ context.Context, arg pgstore.ClaimPendingEmailsParams) ([]pgstore.EmailOutbox, error) {
+	var claimed []pgstore.EmailOutbox
+	for i := range s.emails {
+		email := &s.emails[i]
+		if email.Status != pgstore.EmailStatusPending || email.NextAttemptAt.Time.After(s.now) {
+			continue
+		}
+		if len(claimed) == int(arg.MaxEmails) {
+			break
+		}
+
+		lease := time.Duration(arg.Lease.Microseconds) * time.Microsecond
+		email.NextAttemptAt = pgtype.Timestamptz{Valid: true, Time: s.now.Add(lease)}
+		claimed = append(claimed, *email)
+	}
+	return claimed, nil
+}
+
+func (s *fakeStore) MarkEmailSent(_ context.Context, id uuid.UUID) error {
+	email := s.email(id)
+	email.Status = pgstore.EmailStatusSent
+	email.Attempts++
+	email.LastError = pgtype.Text{}
+	email.SentAt = pgtype.Timestamptz{Valid: true, Time: s.now}
+	return nil
+}
+
+func (s *fakeStore) MarkEmailFailed(_ context.Context, arg pgstore.MarkEmailFailedParams) error {
+	email := s.email(arg.ID)
+	email.Status = arg.Status
+	email.Attempts++
+	email.LastError = arg.LastError
+	email.NextAttemptAt = arg.NextAttemptAt
+	return nil
+}
+
+func (s *fakeStore) email(id uuid.UUID) *pgstore.EmailOutbox {
+	for i := range s.emails {
+		if s.emails[i].ID == id {
+			return &s.emails[i]
+		}
+	}
+	panic("unknown email " + id.String())
+}
+
+// flakySender fails the given number of sends before delivering to the
+// memory backend.
+type flakySender struct {
+	mu       sync.Mutex
+	failures int
+	*memory.Memory
+}
+
+func (s *flakySender) Send(ctx context.Context, msg journeymailer.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("connection refused")
+	}
+	return s.Memory.Send(ctx, msg)
+}
+
+func newTestDispatcher(t *testing.T, store *fakeStore, failures int) (Dispatcher, *memory.Memory) {
+	t.Helper()
+
+	renderer, err := templates.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := token.NewSigner([]byte(strings.Repeat("s", token.MinSecretLength)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent := memory.NewMemory()
+	sender := &flakySender{failures: failures, Memory: sent}
+	m := journeymailer.NewMailer(nil, renderer, sender, tokens, "journey@example.com", "https://journey.example.com")
+
+	return Dispatcher{store, m, zap.NewNop()}, sent
+}
+
+func loginEmail(recipient string, attempts int32, now time.Time) pgstore.EmailOutbox {
+	return pgstore.EmailOutbox{
+		ID:            uuid.New(),
+		Kind:          pgstore.EmailKindLogin,
+		Status:        pgstore.EmailStatusPending,
+		Attempts:      attempts,
+		NextAttemptAt: pgtype.Timestamptz{Valid: true, Time: now},
+		Recipient:     pgtype.Text{Valid: true, String: recipient},
+	}
+}
+
+func TestDispatchSendsPendingEmails(t *testing.T) {
+	now := time.Now()
+	store := &fakeStore{now: now, emails: []pgstore.EmailOutbox{
+		loginEmail("ana@example.com", 0, now),
+		loginEmail("bruno@example.com", 0, now.Add(time.Minute)),
+	}}
+	d, sent := newTestDispatcher(t, store, 0)
+
+	d.dispatch(context.Background())
+
+	messages := sent.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(messages))
+	}
+	if messages[0].To != "ana@example.com" || messages[0].From != "journey@example.com" {
+		t.Errorf("sent message from %q to %q", messages[0].From, messages[0].To)
+	}
+	if !strings.Contains(messages[0].Text, "https://journey.example.com/auth/session?token=") {
+		t.Errorf("message has no sign-in link:\n%s", messages[0].Text)
+	}
+
+	if email := store.emails[0]; email.Status != pgstore.EmailStatusSent || email.Attempts != 1 || !email.SentAt.Valid {
+		t.Errorf("sent email is %s after %d attempts", email.Status, email.Attempts)
+	}
+	if email := store.emails[1]; email.Status != pgstore.EmailStatusPending || email.Attempts != 0 {
+		t.Errorf("email not due yet is %s after %d attempts", email.Status, email.Attempts)
+	}
+
+	// Once sent, an e-mail isn't claimed again.
+	store.now = now.Add(time.Hour)
+	d.dispatch(context.Background())
+	if messages := sent.Messages(); len(messages) != 2 || messages[1].To != "bruno@example.com" {
+		t.Fatalf("sent %d messages, want the second one to bruno@example.com", len(messages))
+	}
+}
+
+func TestDispatchRetriesWithBackoff(t *testing.T) {
+	now := time.Now()
+	store := &fakeStore{now: now, emails: []pgstore.EmailOutbox{
+		loginEmail("ana@example.com", 0, now),
+	}}
+	d, sent := newTestDispatcher(t, store, 2)
+
+	for attempt := int32(1); attempt <= 2; attempt++ {
+		before := time.Now()
+		d.dispatch(context.Background())
+
+		email := store.emails[0]
+		if email.Status != pgstore.EmailStatusPending || email.Attempts != attempt {
+			t.Fatalf("failed email is %s after %d attempts, want pending after %d", email.Status, email.Attempts, attempt)
+		}
+		if !email.LastError.Valid || !strings.Contains(email.LastError.String, "connection refused") {
+			t.Errorf("failed email has last error %q", email.LastError.String)
+		}
+		if delay := email.NextAttemptAt.Time.Sub(before); delay < backoff(attempt) || delay > backoff(attempt)+time.Minute {
+			t.Errorf("attempt %d is retried after %v, want %v", attempt, delay, backoff(attempt))
+		}
+		if messages := sent.Messages(); len(messages) != 0 {
+			t.Fatalf("sent %d messages, want none", len(messages))
+		}
+
+		// It isn't tried again before its backoff is over.
+		store.now = email.NextAttemptAt.Time.Add(-time.Second)
+		d.dispatch(context.Background())
+		if email := store.emails[0]; email.Attempts != attempt {
+			t.Fatalf("email was tried again before its backoff was over")
+		}
+		store.now = email.NextAttemptAt.Time
+	}
+
+	d.dispatch(context.Background())
+	if email := store.emails[0]; email.Status != pgstore.EmailStatusSent || email.Attempts != 3 {
+		t.Errorf("email is %s after %d attempts, want sent after 3", email.Status, email.Attempts)
+	}
+	if messages := sent.Messages(); len(messages) != 1 || messages[0].To != "ana@example.com" {
+		t.Errorf("sent %d messages, want 1 to ana@example.com", len(messages))
+	}
+}
+
+func TestDispatchMovesEmailsToDead(t *testing.T) {
+	now := time.Now()
+	store := &fakeStore{now: now, emails: []pgstore.EmailOutbox{
+		loginEmail("ana@example.com", maxAttempts-1, now),
+		{
+			ID:            uuid.New(),
+			Kind:          "postcard",
+			Status:        pgstore.EmailStatusPending,
+			Attempts:      maxAttempts - 1,
+			NextAttemptAt: pgtype.Timestamptz{Valid: true, Time: now},
+		},
+	}}
+	d, sent := newTestDispatcher(t, store, 1)
+
+	d.dispatch(context.Background())
+
+	for _, email := range store.emails {
+		if email.Status != pgstore.EmailStatusDead || email.Attempts != maxAttempts {
+			t.Errorf("%s email is %s after %d attempts, want dead after %d", email.Kind, email.Status, email.Attempts, maxAttempts)
+		}
+	}
+	if reason := store.emails[1].LastError.String; !strings.Contains(reason, `unknown email kind "postcard"`) {
+		t.Errorf("unknown email failed with %q", reason)
+	}
+
+	// Dead e-mails are never claimed again.
+	store.now = now.Add(24 * time.Hour)
+	d.dispatch(context.Background())
+	if messages := sent.Messages(); len(messages) != 0 {
+		t.Errorf("sent %d messages, want none", len(messages))
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 8, want: time.Hour},
+		{attempts: 20, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
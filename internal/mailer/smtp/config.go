@@ -0,0 +1,61 @@
+package smtp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wneessen/go-mail"
+)
+
+// Config is the SMTP relay used to send e-mails, as set by the JOURNEY_SMTP_*
+// environment variables. TLS is one of the TLS policies below, and Auth the
+// name of a SASL mechanism, such as PLAIN or LOGIN. PLAIN is used when there
+// is a Username but no Auth.
+type Config struct {
+	Host     string
+	Port     int
+	TLS      string
+	Auth     string
+	Username string
+	Password string
+}
+
+// TLS policies accepted in JOURNEY_SMTP_TLS.
+const (
+	TLSNone          = "none"
+	TLSOpportunistic = "opportunistic"
+	TLSMandatory     = "mandatory"
+	TLSImplicit      = "ssl"
+)
+
+func (cfg Config) options() ([]mail.Option, error) {
+	opts := []mail.Option{mail.WithPort(cfg.Port)}
+
+	switch strings.ToLower(cfg.TLS) {
+	case TLSNone:
+		opts = append(opts, mail.WithTLSPolicy(mail.NoTLS))
+	case TLSOpportunistic:
+		opts = append(opts, mail.WithTLSPolicy(mail.TLSOpportunistic))
+	case TLSMandatory:
+		opts = append(opts, mail.WithTLSPolicy(mail.TLSMandatory))
+	case TLSImplicit:
+		opts = append(opts, mail.WithSSL())
+	default:
+		return nil, fmt.Errorf("smtp: invalid JOURNEY_SMTP_TLS %q", cfg.TLS)
+	}
+
+	auth := mail.SMTPAuthType(strings.ToUpper(cfg.Auth))
+	if cfg.Username != "" && auth == mail.SMTPAuthNoAuth {
+		auth = mail.SMTPAuthPlain
+	}
+	if auth != mail.SMTPAuthNoAuth {
+		opts = append(
+			opts,
+			mail.WithSMTPAuth(auth),
+			mail.WithUsername(cfg.Username),
+			mail.WithPassword(cfg.Password),
+		)
+	}
+
+	return opts, nil
+}
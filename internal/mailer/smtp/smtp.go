@@ -0,0 +1,88 @@
+package smtp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"journey/internal/mailer"
+	"sync"
+
+	"github.com/wneessen/go-mail"
+)
+
+// SMTP sends e-mails through an SMTP relay, keeping a single connection open
+// between messages.
+type SMTP struct {
+	mu        sync.Mutex
+	client    *mail.Client
+	connected bool
+}
+
+// NewSMTP returns a sender that delivers through the relay in cfg.
+func NewSMTP(cfg Config) (*SMTP, error) {
+	opts, err := cfg.options()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := mail.NewClient(cfg.Host, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("smtp: failed to create email Client: %w", err)
+	}
+
+	return &SMTP{client: client}, nil
+}
+
+// Close ends the connection with the relay, if there is one.
+func (s *SMTP) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.connected {
+		return nil
+	}
+
+	s.connected = false
+	return s.client.Close()
+}
+
+func (s *SMTP) Send(ctx context.Context, m mailer.Message) error {
+	msg, err := m.Msg()
+	if err != nil {
+		return fmt.Errorf("smtp: failed to build message: %w", err)
+	}
+
+	if err := s.send(ctx, msg); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+
+	return nil
+}
+
+// send delivers msg over the open connection, dialing the relay when there is
+// none yet or when the previous one was dropped.
+func (s *SMTP) send(ctx context.Context, msg *mail.Msg) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.connected {
+		err := s.client.Send(msg)
+
+		// The relay may have closed an idle connection, in which case the
+		// message is tried once more on a fresh one.
+		var sendErr *mail.SendError
+		if !errors.As(err, &sendErr) || sendErr.Reason != mail.ErrConnCheck {
+			return err
+		}
+
+		_ = s.client.Close()
+		s.connected = false
+	}
+
+	if err := s.client.DialWithContext(ctx); err != nil {
+		return fmt.Errorf("dial failed: %w", err)
+	}
+	s.connected = true
+
+	return s.client.Send(msg)
+}
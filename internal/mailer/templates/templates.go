@@ -0,0 +1,176 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed email.html.tmpl email.txt.tmpl catalogs/*.json
+var files embed.FS
+
+// DefaultLocale is used for trips whose locale has no catalog.
+const DefaultLocale = "pt-BR"
+
+// Data holds the values that can be referenced by the catalog messages.
+type Data struct {
+	OwnerName       string
+	ParticipantName string
+	Destination     string
+	StartsAt        time.Time
+	EndsAt          time.Time
+}
+
+// Email is a rendered e-mail, with both an HTML and a plain text body.
+type Email struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+type catalog struct {
+	DateFormat string             `json:"date_format"`
+	LinkHint   string             `json:"link_hint"`
+	Footer     string             `json:"footer"`
+	Messages   map[string]message `json:"messages"`
+}
+
+type message struct {
+	Subject    string   `json:"subject"`
+	Greeting   string   `json:"greeting"`
+	Paragraphs []string `json:"paragraphs"`
+	Action     string   `json:"action"`
+}
+
+type action struct {
+	Label string
+	URL   string
+}
+
+type layout struct {
+	Locale     string
+	Subject    string
+	Greeting   string
+	Paragraphs []string
+	Action     *action
+	LinkHint   string
+	Footer     string
+}
+
+// Renderer renders every e-mail sent by the application from a single
+// HTML/text template pair, filled with messages from the locale catalogs.
+type Renderer struct {
+	html     *htmltemplate.Template
+	text     *texttemplate.Template
+	catalogs map[string]catalog
+}
+
+func NewRenderer() (*Renderer, error) {
+	html, err := htmltemplate.ParseFS(files, "email.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("templates: failed to parse html template: %w", err)
+	}
+
+	text, err := texttemplate.ParseFS(files, "email.txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("templates: failed to parse text template: %w", err)
+	}
+
+	entries, err := files.ReadDir("catalogs")
+	if err != nil {
+		return nil, fmt.Errorf("templates: failed to read catalogs: %w", err)
+	}
+
+	catalogs := make(map[string]catalog, len(entries))
+	for _, entry := range entries {
+		data, err := files.ReadFile(path.Join("catalogs", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("templates: failed to read catalog %s: %w", entry.Name(), err)
+		}
+
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("templates: failed to decode catalog %s: %w", entry.Name(), err)
+		}
+
+		catalogs[strings.TrimSuffix(entry.Name(), ".json")] = c
+	}
+
+	if _, ok := catalogs[DefaultLocale]; !ok {
+		return nil, fmt.Errorf("templates: missing catalog for default locale %s", DefaultLocale)
+	}
+
+	return &Renderer{html, text, catalogs}, nil
+}
+
+// Render renders the e-mail of the given kind in locale, falling back to
+// DefaultLocale. When actionURL is not empty the e-mail gets a button
+// pointing to it.
+func (r *Renderer) Render(locale, kind string, data Data, actionURL string) (Email, error) {
+	c, ok := r.catalogs[locale]
+	if !ok {
+		locale = DefaultLocale
+		c = r.catalogs[locale]
+	}
+
+	msg, ok := c.Messages[kind]
+	if !ok {
+		return Email{}, fmt.Errorf("templates: no %q message for locale %s", kind, locale)
+	}
+
+	funcs := texttemplate.FuncMap{
+		"date": func(t time.Time) string { return t.Format(c.DateFormat) },
+	}
+	execute := func(s string) (string, error) {
+		t, err := texttemplate.New(kind).Funcs(funcs).Parse(s)
+		if err != nil {
+			return "", err
+		}
+
+		var buf strings.Builder
+		if err := t.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
+	l := layout{Locale: locale, LinkHint: c.LinkHint, Footer: c.Footer}
+
+	var err error
+	if l.Subject, err = execute(msg.Subject); err != nil {
+		return Email{}, fmt.Errorf("templates: failed to render %s subject: %w", kind, err)
+	}
+
+	if l.Greeting, err = execute(msg.Greeting); err != nil {
+		return Email{}, fmt.Errorf("templates: failed to render %s greeting: %w", kind, err)
+	}
+
+	for _, p := range msg.Paragraphs {
+		paragraph, err := execute(p)
+		if err != nil {
+			return Email{}, fmt.Errorf("templates: failed to render %s paragraph: %w", kind, err)
+		}
+		l.Paragraphs = append(l.Paragraphs, paragraph)
+	}
+
+	if actionURL != "" {
+		l.Action = &action{Label: msg.Action, URL: actionURL}
+	}
+
+	var html, text bytes.Buffer
+	if err := r.html.Execute(&html, l); err != nil {
+		return Email{}, fmt.Errorf("templates: failed to render %s html: %w", kind, err)
+	}
+
+	if err := r.text.Execute(&text, l); err != nil {
+		return Email{}, fmt.Errorf("templates: failed to render %s text: %w", kind, err)
+	}
+
+	return Email{Subject: l.Subject, HTML: html.String(), Text: text.String()}, nil
+}
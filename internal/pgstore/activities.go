@@ -0,0 +1,17 @@
+package pgstore
+
+// Categories of an activity. Activities created without one are
+// ActivityCategoryOther, which is also the column default.
+const (
+	ActivityCategoryFood        = "food"
+	ActivityCategoryTransport   = "transport"
+	ActivityCategoryLodging     = "lodging"
+	ActivityCategorySightseeing = "sightseeing"
+	ActivityCategoryOther       = "other"
+)
+
+// Frequencies of a recurring activity.
+const (
+	RecurrenceDaily  = "daily"
+	RecurrenceWeekly = "weekly"
+)
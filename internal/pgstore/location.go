@@ -0,0 +1,33 @@
+package pgstore
+
+import "time"
+
+// DefaultTimezone is used for trips created without an explicit time zone.
+const DefaultTimezone = "UTC"
+
+// DefaultLocale is used for trips created without an explicit locale.
+const DefaultLocale = "pt-BR"
+
+// Location returns the trip's time zone, falling back to UTC when the stored
+// name can't be loaded.
+func (t Trip) Location() *time.Location {
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Location returns the activity's own time zone, or the trip's one when the
+// activity doesn't override it.
+func (a Activity) Location(trip Trip) *time.Location {
+	if !a.Timezone.Valid {
+		return trip.Location()
+	}
+
+	loc, err := time.LoadLocation(a.Timezone.String)
+	if err != nil {
+		return trip.Location()
+	}
+	return loc
+}
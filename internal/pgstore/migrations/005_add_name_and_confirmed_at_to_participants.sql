@@ -0,0 +1,9 @@
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS "name"          VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS "confirmed_at"  TIMESTAMP;
+
+---- create above / drop below ----
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS "confirmed_at",
+    DROP COLUMN IF EXISTS "name";
@@ -0,0 +1,27 @@
+-- Existing values were stored without an offset, so they are read as UTC.
+
+ALTER TABLE trips
+    ALTER COLUMN "starts_at" TYPE TIMESTAMPTZ USING "starts_at" AT TIME ZONE 'UTC',
+    ALTER COLUMN "ends_at"   TYPE TIMESTAMPTZ USING "ends_at" AT TIME ZONE 'UTC',
+    ADD COLUMN IF NOT EXISTS "timezone" VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+ALTER TABLE activities
+    ALTER COLUMN "occurs_at" TYPE TIMESTAMPTZ USING "occurs_at" AT TIME ZONE 'UTC',
+    ADD COLUMN IF NOT EXISTS "timezone" VARCHAR(64);
+
+ALTER TABLE participants
+    ALTER COLUMN "confirmed_at" TYPE TIMESTAMPTZ USING "confirmed_at" AT TIME ZONE 'UTC';
+
+---- create above / drop below ----
+
+ALTER TABLE participants
+    ALTER COLUMN "confirmed_at" TYPE TIMESTAMP USING "confirmed_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS "timezone",
+    ALTER COLUMN "occurs_at" TYPE TIMESTAMP USING "occurs_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE trips
+    DROP COLUMN IF EXISTS "timezone",
+    ALTER COLUMN "ends_at"   TYPE TIMESTAMP USING "ends_at" AT TIME ZONE 'UTC',
+    ALTER COLUMN "starts_at" TYPE TIMESTAMP USING "starts_at" AT TIME ZONE 'UTC';
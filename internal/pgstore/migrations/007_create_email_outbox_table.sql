@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS email_outbox (
+    "id"                uuid            PRIMARY KEY NOT NULL    DEFAULT gen_random_uuid(),
+    "kind"              VARCHAR(64)                 NOT NULL,
+    "trip_id"           uuid                        NOT NULL,
+    "participant_id"    uuid,
+    "status"            VARCHAR(16)                 NOT NULL    DEFAULT 'pending',
+    "attempts"          INTEGER                     NOT NULL    DEFAULT 0,
+    "last_error"        TEXT,
+    "next_attempt_at"   TIMESTAMPTZ                 NOT NULL    DEFAULT now(),
+    "created_at"        TIMESTAMPTZ                 NOT NULL    DEFAULT now(),
+    "sent_at"           TIMESTAMPTZ,
+
+    FOREIGN KEY (trip_id) REFERENCES trips(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+
+    FOREIGN KEY (participant_id) REFERENCES participants(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS email_outbox_status_next_attempt_at_idx
+    ON email_outbox ("status", "next_attempt_at");
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS email_outbox;
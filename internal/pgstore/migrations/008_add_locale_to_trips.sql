@@ -0,0 +1,7 @@
+ALTER TABLE trips
+    ADD COLUMN IF NOT EXISTS "locale" VARCHAR(16) NOT NULL DEFAULT 'pt-BR';
+
+---- create above / drop below ----
+
+ALTER TABLE trips
+    DROP COLUMN IF EXISTS "locale";
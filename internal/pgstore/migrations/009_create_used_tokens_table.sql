@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS used_tokens (
+    "id"            uuid            PRIMARY KEY NOT NULL,
+    "expires_at"    TIMESTAMPTZ                 NOT NULL,
+    "used_at"       TIMESTAMPTZ                 NOT NULL    DEFAULT now()
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS used_tokens;
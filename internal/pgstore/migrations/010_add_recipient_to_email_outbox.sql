@@ -0,0 +1,11 @@
+ALTER TABLE email_outbox
+    ALTER COLUMN "trip_id" DROP NOT NULL,
+    ADD COLUMN IF NOT EXISTS "recipient" VARCHAR(255);
+
+---- create above / drop below ----
+
+DELETE FROM email_outbox WHERE "trip_id" IS NULL;
+
+ALTER TABLE email_outbox
+    DROP COLUMN IF EXISTS "recipient",
+    ALTER COLUMN "trip_id" SET NOT NULL;
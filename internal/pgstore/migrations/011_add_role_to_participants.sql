@@ -0,0 +1,7 @@
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS "role" VARCHAR(16) NOT NULL DEFAULT 'member';
+
+---- create above / drop below ----
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,30 @@
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS "status" VARCHAR(16) NOT NULL DEFAULT 'invited';
+
+UPDATE participants
+SET
+    "status" = 'confirmed'
+WHERE
+    "is_confirmed";
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS "is_confirmed";
+
+CREATE INDEX IF NOT EXISTS participants_trip_id_status_idx
+    ON participants ("trip_id", "status");
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS participants_trip_id_status_idx;
+
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS "is_confirmed" BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE participants
+SET
+    "is_confirmed" = true
+WHERE
+    "status" = 'confirmed';
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,29 @@
+UPDATE trips
+SET
+    "owner_email" = lower("owner_email");
+
+DELETE FROM participants
+WHERE "id" IN (
+    SELECT "id"
+    FROM (
+        SELECT
+            "id",
+            row_number() OVER (
+                PARTITION BY "trip_id", lower("email")
+                ORDER BY ("status" = 'confirmed') DESC, "confirmed_at" NULLS LAST, "id"
+            ) AS "n"
+        FROM participants
+    ) AS ranked
+    WHERE "n" > 1
+);
+
+UPDATE participants
+SET
+    "email" = lower("email");
+
+CREATE UNIQUE INDEX IF NOT EXISTS participants_trip_id_email_key
+    ON participants ("trip_id", lower("email"));
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS participants_trip_id_email_key;
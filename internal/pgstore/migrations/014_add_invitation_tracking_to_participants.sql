@@ -0,0 +1,31 @@
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS "invited_at"       TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS "last_invited_at"  TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS "invite_count"     INTEGER     NOT NULL    DEFAULT 0;
+
+UPDATE participants
+SET
+    "invited_at" = sent."first_at",
+    "last_invited_at" = sent."last_at",
+    "invite_count" = sent."count"
+FROM (
+    SELECT
+        "participant_id",
+        min("created_at") AS "first_at",
+        max("created_at") AS "last_at",
+        count(*) AS "count"
+    FROM email_outbox
+    WHERE
+        "kind" = 'invite_participant'
+        AND "participant_id" IS NOT NULL
+    GROUP BY "participant_id"
+) AS sent
+WHERE
+    participants."id" = sent."participant_id";
+
+---- create above / drop below ----
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS "invited_at",
+    DROP COLUMN IF EXISTS "last_invited_at",
+    DROP COLUMN IF EXISTS "invite_count";
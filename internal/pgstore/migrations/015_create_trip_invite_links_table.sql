@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS trip_invite_links (
+    "id"            uuid            PRIMARY KEY NOT NULL    DEFAULT gen_random_uuid(),
+    "trip_id"       uuid                        NOT NULL,
+    "code"          VARCHAR(32)                 NOT NULL    UNIQUE,
+    "created_by"    VARCHAR(255)                NOT NULL,
+    "created_at"    TIMESTAMPTZ                 NOT NULL    DEFAULT now(),
+    "expires_at"    TIMESTAMPTZ,
+    "max_uses"      INTEGER,
+    "uses"          INTEGER                     NOT NULL    DEFAULT 0,
+    "revoked_at"    TIMESTAMPTZ,
+
+    FOREIGN KEY (trip_id) REFERENCES trips(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS trip_invite_links;
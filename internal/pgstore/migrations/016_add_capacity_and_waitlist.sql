@@ -0,0 +1,25 @@
+ALTER TABLE trips
+    ADD COLUMN IF NOT EXISTS "max_participants" INTEGER;
+
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS "waitlisted_at" TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS participants_trip_id_waitlisted_at_idx
+    ON participants ("trip_id", "waitlisted_at")
+    WHERE "status" = 'waitlisted';
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS participants_trip_id_waitlisted_at_idx;
+
+UPDATE participants
+SET
+    "status" = 'invited'
+WHERE
+    "status" = 'waitlisted';
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS "waitlisted_at";
+
+ALTER TABLE trips
+    DROP COLUMN IF EXISTS "max_participants";
@@ -0,0 +1,26 @@
+ALTER TABLE activities
+    ADD COLUMN IF NOT EXISTS "ends_at"          TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS "location_name"    VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS "latitude"         DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS "longitude"        DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS "notes"            TEXT,
+    ADD COLUMN IF NOT EXISTS "category"         VARCHAR(16)     NOT NULL    DEFAULT 'other',
+    ADD COLUMN IF NOT EXISTS "cost_amount"      NUMERIC(12, 2),
+    ADD COLUMN IF NOT EXISTS "cost_currency"    VARCHAR(3);
+
+CREATE INDEX IF NOT EXISTS activities_trip_id_category_idx
+    ON activities ("trip_id", "category");
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS activities_trip_id_category_idx;
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS "ends_at",
+    DROP COLUMN IF EXISTS "location_name",
+    DROP COLUMN IF EXISTS "latitude",
+    DROP COLUMN IF EXISTS "longitude",
+    DROP COLUMN IF EXISTS "notes",
+    DROP COLUMN IF EXISTS "category",
+    DROP COLUMN IF EXISTS "cost_amount",
+    DROP COLUMN IF EXISTS "cost_currency";
@@ -0,0 +1,22 @@
+-- activity_period is the time span taken by an activity. One without an end
+-- (or ending when it starts) takes a single instant, so it still overlaps
+-- whatever is going on at that moment, while back-to-back activities don't.
+CREATE OR REPLACE FUNCTION activity_period("occurs_at" TIMESTAMPTZ, "ends_at" TIMESTAMPTZ)
+RETURNS TSTZRANGE
+LANGUAGE SQL
+IMMUTABLE
+AS $$
+    SELECT CASE
+        WHEN "ends_at" IS NULL OR "ends_at" <= "occurs_at" THEN tstzrange("occurs_at", "occurs_at", '[]')
+        ELSE tstzrange("occurs_at", "ends_at", '[)')
+    END
+$$;
+
+CREATE INDEX IF NOT EXISTS activities_period_idx
+    ON activities USING GIST (activity_period("occurs_at", "ends_at"));
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS activities_period_idx;
+
+DROP FUNCTION IF EXISTS activity_period(TIMESTAMPTZ, TIMESTAMPTZ);
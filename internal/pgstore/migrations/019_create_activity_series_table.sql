@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS activity_series (
+    "id"            uuid            PRIMARY KEY NOT NULL    DEFAULT gen_random_uuid(),
+    "trip_id"       uuid                        NOT NULL,
+    "frequency"     VARCHAR(8)                  NOT NULL,
+    "interval"      INTEGER                     NOT NULL    DEFAULT 1,
+    "weekdays"      TEXT[]                      NOT NULL    DEFAULT '{}',
+    "until"         TIMESTAMPTZ,
+
+    FOREIGN KEY (trip_id) REFERENCES trips(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+ALTER TABLE activities
+    ADD COLUMN IF NOT EXISTS "series_id" uuid REFERENCES activity_series(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS activities_series_id_idx
+    ON activities ("series_id")
+    WHERE "series_id" IS NOT NULL;
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS activities_series_id_idx;
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS "series_id";
+
+DROP TABLE IF EXISTS activity_series;
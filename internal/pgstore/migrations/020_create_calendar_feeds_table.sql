@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    "id"            uuid            PRIMARY KEY NOT NULL    DEFAULT gen_random_uuid(),
+    "trip_id"       uuid                        NOT NULL,
+    "email"         VARCHAR(255)                NOT NULL,
+    "token"         VARCHAR(64)                 NOT NULL    UNIQUE,
+    "created_at"    TIMESTAMPTZ                 NOT NULL    DEFAULT now(),
+
+    FOREIGN KEY (trip_id) REFERENCES trips(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS calendar_feeds_trip_id_email_key
+    ON calendar_feeds ("trip_id", lower("email"));
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS calendar_feeds;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS activity_imports (
+    "activity_id"   uuid            PRIMARY KEY NOT NULL,
+    "trip_id"       uuid                        NOT NULL,
+    "uid"           TEXT                        NOT NULL,
+    "imported_at"   TIMESTAMPTZ                 NOT NULL    DEFAULT now(),
+
+    FOREIGN KEY (activity_id) REFERENCES activities(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+
+    FOREIGN KEY (trip_id) REFERENCES trips(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+
+    UNIQUE ("trip_id", "uid")
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS activity_imports;
@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS email_outbox_login_recipient_created_at_idx
+    ON email_outbox ("recipient", "created_at")
+    WHERE "kind" = 'login';
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS email_outbox_login_recipient_created_at_idx;
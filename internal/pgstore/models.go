@@ -10,10 +10,58 @@ import (
 )
 
 type Activity struct {
-	ID       uuid.UUID        `db:"id" json:"id"`
-	TripID   uuid.UUID        `db:"trip_id" json:"trip_id"`
-	Title    string           `db:"title" json:"title"`
-	OccursAt pgtype.Timestamp `db:"occurs_at" json:"occurs_at"`
+	ID           uuid.UUID          `db:"id" json:"id"`
+	TripID       uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Title        string             `db:"title" json:"title"`
+	OccursAt     pgtype.Timestamptz `db:"occurs_at" json:"occurs_at"`
+	Timezone     pgtype.Text        `db:"timezone" json:"timezone"`
+	EndsAt       pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	LocationName pgtype.Text        `db:"location_name" json:"location_name"`
+	Latitude     pgtype.Float8      `db:"latitude" json:"latitude"`
+	Longitude    pgtype.Float8      `db:"longitude" json:"longitude"`
+	Notes        pgtype.Text        `db:"notes" json:"notes"`
+	Category     string             `db:"category" json:"category"`
+	CostAmount   pgtype.Numeric     `db:"cost_amount" json:"cost_amount"`
+	CostCurrency pgtype.Text        `db:"cost_currency" json:"cost_currency"`
+	SeriesID     pgtype.UUID        `db:"series_id" json:"series_id"`
+}
+
+type ActivityImport struct {
+	ActivityID uuid.UUID          `db:"activity_id" json:"activity_id"`
+	TripID     uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Uid        string             `db:"uid" json:"uid"`
+	ImportedAt pgtype.Timestamptz `db:"imported_at" json:"imported_at"`
+}
+
+type ActivitySeries struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Frequency string             `db:"frequency" json:"frequency"`
+	Interval  int32              `db:"interval" json:"interval"`
+	Weekdays  []string           `db:"weekdays" json:"weekdays"`
+	Until     pgtype.Timestamptz `db:"until" json:"until"`
+}
+
+type CalendarFeed struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Email     string             `db:"email" json:"email"`
+	Token     string             `db:"token" json:"token"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type EmailOutbox struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	Kind          string             `db:"kind" json:"kind"`
+	TripID        pgtype.UUID        `db:"trip_id" json:"trip_id"`
+	ParticipantID pgtype.UUID        `db:"participant_id" json:"participant_id"`
+	Status        string             `db:"status" json:"status"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	LastError     pgtype.Text        `db:"last_error" json:"last_error"`
+	NextAttemptAt pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	SentAt        pgtype.Timestamptz `db:"sent_at" json:"sent_at"`
+	Recipient     pgtype.Text        `db:"recipient" json:"recipient"`
 }
 
 type Link struct {
@@ -24,18 +72,46 @@ type Link struct {
 }
 
 type Participant struct {
-	ID          uuid.UUID `db:"id" json:"id"`
-	TripID      uuid.UUID `db:"trip_id" json:"trip_id"`
-	Email       string    `db:"email" json:"email"`
-	IsConfirmed bool      `db:"is_confirmed" json:"is_confirmed"`
+	ID            uuid.UUID          `db:"id" json:"id"`
+	TripID        uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Email         string             `db:"email" json:"email"`
+	Name          pgtype.Text        `db:"name" json:"name"`
+	ConfirmedAt   pgtype.Timestamptz `db:"confirmed_at" json:"confirmed_at"`
+	Role          string             `db:"role" json:"role"`
+	Status        string             `db:"status" json:"status"`
+	InvitedAt     pgtype.Timestamptz `db:"invited_at" json:"invited_at"`
+	LastInvitedAt pgtype.Timestamptz `db:"last_invited_at" json:"last_invited_at"`
+	InviteCount   int32              `db:"invite_count" json:"invite_count"`
+	WaitlistedAt  pgtype.Timestamptz `db:"waitlisted_at" json:"waitlisted_at"`
 }
 
 type Trip struct {
-	ID          uuid.UUID        `db:"id" json:"id"`
-	Destination string           `db:"destination" json:"destination"`
-	OwnerEmail  string           `db:"owner_email" json:"owner_email"`
-	OwnerName   string           `db:"owner_name" json:"owner_name"`
-	IsConfirmed bool             `db:"is_confirmed" json:"is_confirmed"`
-	StartsAt    pgtype.Timestamp `db:"starts_at" json:"starts_at"`
-	EndsAt      pgtype.Timestamp `db:"ends_at" json:"ends_at"`
+	ID              uuid.UUID          `db:"id" json:"id"`
+	Destination     string             `db:"destination" json:"destination"`
+	OwnerEmail      string             `db:"owner_email" json:"owner_email"`
+	OwnerName       string             `db:"owner_name" json:"owner_name"`
+	IsConfirmed     bool               `db:"is_confirmed" json:"is_confirmed"`
+	StartsAt        pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	EndsAt          pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	Timezone        string             `db:"timezone" json:"timezone"`
+	Locale          string             `db:"locale" json:"locale"`
+	MaxParticipants pgtype.Int4        `db:"max_participants" json:"max_participants"`
+}
+
+type TripInviteLink struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Code      string             `db:"code" json:"code"`
+	CreatedBy string             `db:"created_by" json:"created_by"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	MaxUses   pgtype.Int4        `db:"max_uses" json:"max_uses"`
+	Uses      int32              `db:"uses" json:"uses"`
+	RevokedAt pgtype.Timestamptz `db:"revoked_at" json:"revoked_at"`
+}
+
+type UsedToken struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `db:"used_at" json:"used_at"`
 }
@@ -0,0 +1,18 @@
+package pgstore
+
+// Kinds of e-mail stored in the email_outbox table.
+const (
+	EmailKindConfirmTrip       = "confirm_trip"
+	EmailKindInviteParticipant = "invite_participant"
+	EmailKindLogin             = "login"
+	EmailKindWaitlistPromoted  = "waitlist_promoted"
+)
+
+// Statuses of an email_outbox row. Pending rows are picked up by the
+// dispatcher until they are sent, or until they run out of attempts and are
+// moved to dead.
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSent    = "sent"
+	EmailStatusDead    = "dead"
+)
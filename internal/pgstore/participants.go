@@ -0,0 +1,49 @@
+package pgstore
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Roles on a trip. The owner is the one stored in trips.owner_email and has
+// no participants row, so RoleOwner is never stored in participants.role.
+const (
+	RoleOwner       = "owner"
+	RoleCoOrganizer = "co-organizer"
+	RoleMember      = "member"
+	RoleViewer      = "viewer"
+)
+
+// Statuses of a participant. Invitees start as invited and either confirm or
+// decline; organizers can remove anyone, which keeps the row for the record.
+// Confirmations on a full trip land on the waitlist instead, until a seat is
+// freed.
+const (
+	ParticipantStatusInvited    = "invited"
+	ParticipantStatusConfirmed  = "confirmed"
+	ParticipantStatusWaitlisted = "waitlisted"
+	ParticipantStatusDeclined   = "declined"
+	ParticipantStatusRemoved    = "removed"
+)
+
+// Outcomes of inviting an e-mail to a trip.
+const (
+	InviteOutcomeCreated        = "created"
+	InviteOutcomeAlreadyInvited = "already_invited"
+	InviteOutcomeOwner          = "owner"
+)
+
+// InviteResult is what happened to one of the e-mails given to
+// InviteParticipants. ParticipantID is the zero UUID for InviteOutcomeOwner.
+type InviteResult struct {
+	Email         string
+	Outcome       string
+	ParticipantID uuid.UUID
+}
+
+// NormalizeEmail returns email the way it is stored: trimmed and lowercase.
+// participants has a unique index on (trip_id, lower(email)).
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -12,39 +12,222 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimPendingEmails = `-- name: ClaimPendingEmails :many
+UPDATE email_outbox
+SET
+    "next_attempt_at" = now() + $1::interval
+WHERE
+    id IN (
+        SELECT "id"
+        FROM email_outbox
+        WHERE
+            "status" = 'pending'
+            AND "next_attempt_at" <= now()
+        ORDER BY "next_attempt_at"
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    )
+RETURNING
+    "id", "kind", "trip_id", "participant_id", "status", "attempts", "last_error", "next_attempt_at", "created_at", "sent_at", "recipient"
+`
+
+type ClaimPendingEmailsParams struct {
+	Lease     pgtype.Interval `db:"lease" json:"lease"`
+	MaxEmails int32           `db:"max_emails" json:"max_emails"`
+}
+
+func (q *Queries) ClaimPendingEmails(ctx context.Context, arg ClaimPendingEmailsParams) ([]EmailOutbox, error) {
+	rows, err := q.db.Query(ctx, claimPendingEmails, arg.Lease, arg.MaxEmails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EmailOutbox
+	for rows.Next() {
+		var i EmailOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.TripID,
+			&i.ParticipantID,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.SentAt,
+			&i.Recipient,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const confirmParticipant = `-- name: ConfirmParticipant :exec
-SELECT
-    "id", "trip_id", "email", "is_confirmed"
-FROM participants
+UPDATE participants
+SET
+    "status" = 'confirmed',
+    "name" = COALESCE($1, "name"),
+    "confirmed_at" = now()
+WHERE
+    id = $2
+`
+
+type ConfirmParticipantParams struct {
+	Name pgtype.Text `db:"name" json:"name"`
+	ID   uuid.UUID   `db:"id" json:"id"`
+}
+
+func (q *Queries) ConfirmParticipant(ctx context.Context, arg ConfirmParticipantParams) error {
+	_, err := q.db.Exec(ctx, confirmParticipant, arg.Name, arg.ID)
+	return err
+}
+
+const confirmTrip = `-- name: ConfirmTrip :exec
+UPDATE trips
+SET
+    "is_confirmed" = true
 WHERE
     id = $1
 `
 
-func (q *Queries) ConfirmParticipant(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, confirmParticipant, id)
+func (q *Queries) ConfirmTrip(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, confirmTrip, id)
 	return err
 }
 
+const countConfirmedParticipants = `-- name: CountConfirmedParticipants :one
+SELECT
+    count(*)
+FROM participants
+WHERE
+    trip_id = $1
+    AND "status" = 'confirmed'
+`
+
+func (q *Queries) CountConfirmedParticipants(ctx context.Context, tripID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countConfirmedParticipants, tripID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createActivity = `-- name: CreateActivity :one
 INSERT INTO activities
-    ( "trip_id", "title", "occurs_at" ) VALUES
-    ( $1, $2, $3 )
+    ( "trip_id", "title", "occurs_at", "timezone", "ends_at", "location_name", "latitude", "longitude", "notes", "category", "cost_amount", "cost_currency", "series_id" ) VALUES
+    ( $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13 )
 RETURNING "id"
 `
 
 type CreateActivityParams struct {
-	TripID   uuid.UUID        `db:"trip_id" json:"trip_id"`
-	Title    string           `db:"title" json:"title"`
-	OccursAt pgtype.Timestamp `db:"occurs_at" json:"occurs_at"`
+	TripID       uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Title        string             `db:"title" json:"title"`
+	OccursAt     pgtype.Timestamptz `db:"occurs_at" json:"occurs_at"`
+	Timezone     pgtype.Text        `db:"timezone" json:"timezone"`
+	EndsAt       pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	LocationName pgtype.Text        `db:"location_name" json:"location_name"`
+	Latitude     pgtype.Float8      `db:"latitude" json:"latitude"`
+	Longitude    pgtype.Float8      `db:"longitude" json:"longitude"`
+	Notes        pgtype.Text        `db:"notes" json:"notes"`
+	Category     string             `db:"category" json:"category"`
+	CostAmount   pgtype.Numeric     `db:"cost_amount" json:"cost_amount"`
+	CostCurrency pgtype.Text        `db:"cost_currency" json:"cost_currency"`
+	SeriesID     pgtype.UUID        `db:"series_id" json:"series_id"`
 }
 
 func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, createActivity, arg.TripID, arg.Title, arg.OccursAt)
+	row := q.db.QueryRow(ctx, createActivity,
+		arg.TripID,
+		arg.Title,
+		arg.OccursAt,
+		arg.Timezone,
+		arg.EndsAt,
+		arg.LocationName,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Notes,
+		arg.Category,
+		arg.CostAmount,
+		arg.CostCurrency,
+		arg.SeriesID,
+	)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
 }
 
+const createCalendarFeed = `-- name: CreateCalendarFeed :one
+INSERT INTO calendar_feeds
+    ( "trip_id", "email", "token" ) VALUES
+    ( $1, $2, $3 )
+ON CONFLICT ("trip_id", lower("email")) DO UPDATE
+SET
+    "email" = EXCLUDED."email"
+RETURNING "id", "trip_id", "email", "token", "created_at"
+`
+
+type CreateCalendarFeedParams struct {
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+	Email  string    `db:"email" json:"email"`
+	Token  string    `db:"token" json:"token"`
+}
+
+func (q *Queries) CreateCalendarFeed(ctx context.Context, arg CreateCalendarFeedParams) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, createCalendarFeed, arg.TripID, arg.Email, arg.Token)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Email,
+		&i.Token,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createTripInviteLink = `-- name: CreateTripInviteLink :one
+INSERT INTO trip_invite_links
+    ( "trip_id", "code", "created_by", "expires_at", "max_uses" ) VALUES
+    ( $1, $2, $3, $4, $5 )
+RETURNING "id", "trip_id", "code", "created_by", "created_at", "expires_at", "max_uses", "uses", "revoked_at"
+`
+
+type CreateTripInviteLinkParams struct {
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Code      string             `db:"code" json:"code"`
+	CreatedBy string             `db:"created_by" json:"created_by"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	MaxUses   pgtype.Int4        `db:"max_uses" json:"max_uses"`
+}
+
+func (q *Queries) CreateTripInviteLink(ctx context.Context, arg CreateTripInviteLinkParams) (TripInviteLink, error) {
+	row := q.db.QueryRow(ctx, createTripInviteLink,
+		arg.TripID,
+		arg.Code,
+		arg.CreatedBy,
+		arg.ExpiresAt,
+		arg.MaxUses,
+	)
+	var i TripInviteLink
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Code,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.MaxUses,
+		&i.Uses,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
 const createTripLink = `-- name: CreateTripLink :one
 INSERT INTO links
     ( "trip_id", "title", "url" ) VALUES
@@ -65,9 +248,253 @@ func (q *Queries) CreateTripLink(ctx context.Context, arg CreateTripLinkParams)
 	return id, err
 }
 
+const deleteActivity = `-- name: DeleteActivity :exec
+DELETE FROM activities
+WHERE
+    id = $1
+`
+
+func (q *Queries) DeleteActivity(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteActivity, id)
+	return err
+}
+
+const deleteActivitySeries = `-- name: DeleteActivitySeries :exec
+DELETE FROM activity_series
+WHERE
+    id = $1
+`
+
+func (q *Queries) DeleteActivitySeries(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteActivitySeries, id)
+	return err
+}
+
+const deleteCalendarFeed = `-- name: DeleteCalendarFeed :execrows
+DELETE FROM calendar_feeds
+WHERE
+    trip_id = $1
+    AND lower("email") = lower($2)
+`
+
+type DeleteCalendarFeedParams struct {
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+	Email  string    `db:"email" json:"email"`
+}
+
+func (q *Queries) DeleteCalendarFeed(ctx context.Context, arg DeleteCalendarFeedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCalendarFeed, arg.TripID, arg.Email)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteParticipant = `-- name: DeleteParticipant :exec
+DELETE FROM participants
+WHERE
+    id = $1
+`
+
+func (q *Queries) DeleteParticipant(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteParticipant, id)
+	return err
+}
+
+const enqueueEmail = `-- name: EnqueueEmail :exec
+INSERT INTO email_outbox
+    ( "kind", "trip_id", "participant_id", "recipient" ) VALUES
+    ( $1, $2, $3, $4 )
+`
+
+type EnqueueEmailParams struct {
+	Kind          string      `db:"kind" json:"kind"`
+	TripID        pgtype.UUID `db:"trip_id" json:"trip_id"`
+	ParticipantID pgtype.UUID `db:"participant_id" json:"participant_id"`
+	Recipient     pgtype.Text `db:"recipient" json:"recipient"`
+}
+
+func (q *Queries) EnqueueEmail(ctx context.Context, arg EnqueueEmailParams) error {
+	_, err := q.db.Exec(ctx, enqueueEmail,
+		arg.Kind,
+		arg.TripID,
+		arg.ParticipantID,
+		arg.Recipient,
+	)
+	return err
+}
+
+const getActivity = `-- name: GetActivity :one
+SELECT
+    "id", "trip_id", "title", "occurs_at", "timezone", "ends_at", "location_name", "latitude", "longitude", "notes", "category", "cost_amount", "cost_currency", "series_id"
+FROM activities
+WHERE
+    id = $1
+`
+
+func (q *Queries) GetActivity(ctx context.Context, id uuid.UUID) (Activity, error) {
+	row := q.db.QueryRow(ctx, getActivity, id)
+	var i Activity
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Title,
+		&i.OccursAt,
+		&i.Timezone,
+		&i.EndsAt,
+		&i.LocationName,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Notes,
+		&i.Category,
+		&i.CostAmount,
+		&i.CostCurrency,
+		&i.SeriesID,
+	)
+	return i, err
+}
+
+const getActivityOverlaps = `-- name: GetActivityOverlaps :many
+SELECT
+    b."id", b."trip_id", b."title", b."occurs_at", b."timezone", b."ends_at", b."location_name", b."latitude", b."longitude", b."notes", b."category", b."cost_amount", b."cost_currency", b."series_id"
+FROM activities a
+JOIN activities b
+    ON b."trip_id" = a."trip_id"
+    AND b."id" <> a."id"
+    AND activity_period(b."occurs_at", b."ends_at") && activity_period(a."occurs_at", a."ends_at")
+WHERE
+    a."id" = $1
+ORDER BY
+    b."occurs_at"
+`
+
+func (q *Queries) GetActivityOverlaps(ctx context.Context, id uuid.UUID) ([]Activity, error) {
+	rows, err := q.db.Query(ctx, getActivityOverlaps, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Activity
+	for rows.Next() {
+		var i Activity
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.Title,
+			&i.OccursAt,
+			&i.Timezone,
+			&i.EndsAt,
+			&i.LocationName,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Notes,
+			&i.Category,
+			&i.CostAmount,
+			&i.CostCurrency,
+			&i.SeriesID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getActivitySeriesOverlaps = `-- name: GetActivitySeriesOverlaps :many
+SELECT DISTINCT
+    b."id", b."trip_id", b."title", b."occurs_at", b."timezone", b."ends_at", b."location_name", b."latitude", b."longitude", b."notes", b."category", b."cost_amount", b."cost_currency", b."series_id"
+FROM activities a
+JOIN activities b
+    ON b."trip_id" = a."trip_id"
+    AND b."series_id" IS DISTINCT FROM a."series_id"
+    AND activity_period(b."occurs_at", b."ends_at") && activity_period(a."occurs_at", a."ends_at")
+WHERE
+    a."series_id" = $1::uuid
+ORDER BY
+    b."occurs_at"
+`
+
+func (q *Queries) GetActivitySeriesOverlaps(ctx context.Context, seriesID uuid.UUID) ([]Activity, error) {
+	rows, err := q.db.Query(ctx, getActivitySeriesOverlaps, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Activity
+	for rows.Next() {
+		var i Activity
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.Title,
+			&i.OccursAt,
+			&i.Timezone,
+			&i.EndsAt,
+			&i.LocationName,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Notes,
+			&i.Category,
+			&i.CostAmount,
+			&i.CostCurrency,
+			&i.SeriesID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCalendarFeedByToken = `-- name: GetCalendarFeedByToken :one
+SELECT
+    "id", "trip_id", "email", "token", "created_at"
+FROM calendar_feeds
+WHERE
+    token = $1
+`
+
+func (q *Queries) GetCalendarFeedByToken(ctx context.Context, token string) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, getCalendarFeedByToken, token)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Email,
+		&i.Token,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLastLoginEmailAt = `-- name: GetLastLoginEmailAt :one
+SELECT
+    "created_at"
+FROM email_outbox
+WHERE
+    "kind" = 'login'
+    AND "recipient" = $1
+ORDER BY
+    "created_at" DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastLoginEmailAt(ctx context.Context, recipient pgtype.Text) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getLastLoginEmailAt, recipient)
+	var created_at pgtype.Timestamptz
+	err := row.Scan(&created_at)
+	return created_at, err
+}
+
 const getParticipant = `-- name: GetParticipant :one
 SELECT
-    "id", "trip_id", "email", "is_confirmed"
+    "id", "trip_id", "email", "name", "confirmed_at", "role", "status", "invited_at", "last_invited_at", "invite_count", "waitlisted_at"
 FROM participants
 WHERE
     id = $1
@@ -80,21 +507,34 @@ func (q *Queries) GetParticipant(ctx context.Context, id uuid.UUID) (Participant
 		&i.ID,
 		&i.TripID,
 		&i.Email,
-		&i.IsConfirmed,
+		&i.Name,
+		&i.ConfirmedAt,
+		&i.Role,
+		&i.Status,
+		&i.InvitedAt,
+		&i.LastInvitedAt,
+		&i.InviteCount,
+		&i.WaitlistedAt,
 	)
 	return i, err
 }
 
 const getParticipants = `-- name: GetParticipants :many
 SELECT
-    "id", "trip_id", "email", "is_confirmed"
+    "id", "trip_id", "email", "name", "confirmed_at", "role", "status", "invited_at", "last_invited_at", "invite_count", "waitlisted_at"
 FROM participants
 WHERE
     trip_id = $1
+    AND ($2::text IS NULL OR "status" = $2)
 `
 
-func (q *Queries) GetParticipants(ctx context.Context, tripID uuid.UUID) ([]Participant, error) {
-	rows, err := q.db.Query(ctx, getParticipants, tripID)
+type GetParticipantsParams struct {
+	TripID uuid.UUID   `db:"trip_id" json:"trip_id"`
+	Status pgtype.Text `db:"status" json:"status"`
+}
+
+func (q *Queries) GetParticipants(ctx context.Context, arg GetParticipantsParams) ([]Participant, error) {
+	rows, err := q.db.Query(ctx, getParticipants, arg.TripID, arg.Status)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +546,14 @@ func (q *Queries) GetParticipants(ctx context.Context, tripID uuid.UUID) ([]Part
 			&i.ID,
 			&i.TripID,
 			&i.Email,
-			&i.IsConfirmed,
+			&i.Name,
+			&i.ConfirmedAt,
+			&i.Role,
+			&i.Status,
+			&i.InvitedAt,
+			&i.LastInvitedAt,
+			&i.InviteCount,
+			&i.WaitlistedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -120,7 +567,7 @@ func (q *Queries) GetParticipants(ctx context.Context, tripID uuid.UUID) ([]Part
 
 const getTrip = `-- name: GetTrip :one
 SELECT
-    "id", "destination", "owner_email", "owner_name", "is_confirmed", "starts_at", "ends_at"
+    "id", "destination", "owner_email", "owner_name", "is_confirmed", "starts_at", "ends_at", "timezone", "locale", "max_participants"
 FROM trips
 WHERE
     id = $1
@@ -137,20 +584,31 @@ func (q *Queries) GetTrip(ctx context.Context, id uuid.UUID) (Trip, error) {
 		&i.IsConfirmed,
 		&i.StartsAt,
 		&i.EndsAt,
+		&i.Timezone,
+		&i.Locale,
+		&i.MaxParticipants,
 	)
 	return i, err
 }
 
 const getTripActivities = `-- name: GetTripActivities :many
 SELECT
-    "id", "trip_id", "title", "occurs_at"
+    "id", "trip_id", "title", "occurs_at", "timezone", "ends_at", "location_name", "latitude", "longitude", "notes", "category", "cost_amount", "cost_currency", "series_id"
 FROM activities
 WHERE
     trip_id = $1
+    AND ($2::text IS NULL OR "category" = $2)
+ORDER BY
+    "occurs_at"
 `
 
-func (q *Queries) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]Activity, error) {
-	rows, err := q.db.Query(ctx, getTripActivities, tripID)
+type GetTripActivitiesParams struct {
+	TripID   uuid.UUID   `db:"trip_id" json:"trip_id"`
+	Category pgtype.Text `db:"category" json:"category"`
+}
+
+func (q *Queries) GetTripActivities(ctx context.Context, arg GetTripActivitiesParams) ([]Activity, error) {
+	rows, err := q.db.Query(ctx, getTripActivities, arg.TripID, arg.Category)
 	if err != nil {
 		return nil, err
 	}
@@ -163,6 +621,16 @@ func (q *Queries) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]Ac
 			&i.TripID,
 			&i.Title,
 			&i.OccursAt,
+			&i.Timezone,
+			&i.EndsAt,
+			&i.LocationName,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Notes,
+			&i.Category,
+			&i.CostAmount,
+			&i.CostCurrency,
+			&i.SeriesID,
 		); err != nil {
 			return nil, err
 		}
@@ -174,28 +642,47 @@ func (q *Queries) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]Ac
 	return items, nil
 }
 
-const getTripLinks = `-- name: GetTripLinks :many
+const getTripActivitiesOutsideRange = `-- name: GetTripActivitiesOutsideRange :many
 SELECT
-    "id", "trip_id", "title", "url"
-FROM links
+    "id", "trip_id", "title", "occurs_at", "timezone", "ends_at", "location_name", "latitude", "longitude", "notes", "category", "cost_amount", "cost_currency", "series_id"
+FROM activities
 WHERE
     trip_id = $1
+    AND ("occurs_at" < $2 OR COALESCE("ends_at", "occurs_at") > $3)
+ORDER BY
+    "occurs_at"
 `
 
-func (q *Queries) GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]Link, error) {
-	rows, err := q.db.Query(ctx, getTripLinks, tripID)
+type GetTripActivitiesOutsideRangeParams struct {
+	TripID   uuid.UUID          `db:"trip_id" json:"trip_id"`
+	StartsAt pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	EndsAt   pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+}
+
+func (q *Queries) GetTripActivitiesOutsideRange(ctx context.Context, arg GetTripActivitiesOutsideRangeParams) ([]Activity, error) {
+	rows, err := q.db.Query(ctx, getTripActivitiesOutsideRange, arg.TripID, arg.StartsAt, arg.EndsAt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Link
+	var items []Activity
 	for rows.Next() {
-		var i Link
+		var i Activity
 		if err := rows.Scan(
 			&i.ID,
 			&i.TripID,
 			&i.Title,
-			&i.Url,
+			&i.OccursAt,
+			&i.Timezone,
+			&i.EndsAt,
+			&i.LocationName,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Notes,
+			&i.Category,
+			&i.CostAmount,
+			&i.CostCurrency,
+			&i.SeriesID,
 		); err != nil {
 			return nil, err
 		}
@@ -207,57 +694,717 @@ func (q *Queries) GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]Link, e
 	return items, nil
 }
 
-const insertTrip = `-- name: InsertTrip :one
-INSERT
-INTO trips
-    ( "destination", "owner_email", "owner_name", "starts_at", "ends_at") VALUES
-    ( $1, $2, $3, $4, $5 )
-RETURNING "id"
+const getTripActivityOverlaps = `-- name: GetTripActivityOverlaps :many
+SELECT
+    a."id" AS "first_id", b."id" AS "second_id"
+FROM activities a
+JOIN activities b
+    ON b."trip_id" = a."trip_id"
+    AND (a."occurs_at", a."id") < (b."occurs_at", b."id")
+    AND activity_period(a."occurs_at", a."ends_at") && activity_period(b."occurs_at", b."ends_at")
+WHERE
+    a."trip_id" = $1
+ORDER BY
+    a."occurs_at", b."occurs_at"
 `
 
-type InsertTripParams struct {
-	Destination string           `db:"destination" json:"destination"`
-	OwnerEmail  string           `db:"owner_email" json:"owner_email"`
-	OwnerName   string           `db:"owner_name" json:"owner_name"`
-	StartsAt    pgtype.Timestamp `db:"starts_at" json:"starts_at"`
-	EndsAt      pgtype.Timestamp `db:"ends_at" json:"ends_at"`
+type GetTripActivityOverlapsRow struct {
+	FirstID  uuid.UUID `db:"first_id" json:"first_id"`
+	SecondID uuid.UUID `db:"second_id" json:"second_id"`
 }
 
-func (q *Queries) InsertTrip(ctx context.Context, arg InsertTripParams) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, insertTrip,
-		arg.Destination,
-		arg.OwnerEmail,
-		arg.OwnerName,
-		arg.StartsAt,
-		arg.EndsAt,
-	)
-	var id uuid.UUID
-	err := row.Scan(&id)
-	return id, err
+func (q *Queries) GetTripActivityOverlaps(ctx context.Context, tripID uuid.UUID) ([]GetTripActivityOverlapsRow, error) {
+	rows, err := q.db.Query(ctx, getTripActivityOverlaps, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTripActivityOverlapsRow
+	for rows.Next() {
+		var i GetTripActivityOverlapsRow
+		if err := rows.Scan(&i.FirstID, &i.SecondID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-type InviteParticipantsToTripParams struct {
+const getTripCapacityForUpdate = `-- name: GetTripCapacityForUpdate :one
+SELECT
+    "max_participants"
+FROM trips
+WHERE
+    id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetTripCapacityForUpdate(ctx context.Context, id uuid.UUID) (pgtype.Int4, error) {
+	row := q.db.QueryRow(ctx, getTripCapacityForUpdate, id)
+	var max_participants pgtype.Int4
+	err := row.Scan(&max_participants)
+	return max_participants, err
+}
+
+const getTripImportedUIDs = `-- name: GetTripImportedUIDs :many
+SELECT
+    "uid"
+FROM activity_imports
+WHERE
+    trip_id = $1
+`
+
+func (q *Queries) GetTripImportedUIDs(ctx context.Context, tripID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, getTripImportedUIDs, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var uid string
+		if err := rows.Scan(&uid); err != nil {
+			return nil, err
+		}
+		items = append(items, uid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTripInviteLinkByCode = `-- name: GetTripInviteLinkByCode :one
+SELECT
+    "id", "trip_id", "code", "created_by", "created_at", "expires_at", "max_uses", "uses", "revoked_at"
+FROM trip_invite_links
+WHERE
+    code = $1
+`
+
+func (q *Queries) GetTripInviteLinkByCode(ctx context.Context, code string) (TripInviteLink, error) {
+	row := q.db.QueryRow(ctx, getTripInviteLinkByCode, code)
+	var i TripInviteLink
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Code,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.MaxUses,
+		&i.Uses,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getTripInviteLinks = `-- name: GetTripInviteLinks :many
+SELECT
+    "id", "trip_id", "code", "created_by", "created_at", "expires_at", "max_uses", "uses", "revoked_at"
+FROM trip_invite_links
+WHERE
+    trip_id = $1
+ORDER BY
+    "created_at" DESC
+`
+
+func (q *Queries) GetTripInviteLinks(ctx context.Context, tripID uuid.UUID) ([]TripInviteLink, error) {
+	rows, err := q.db.Query(ctx, getTripInviteLinks, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TripInviteLink
+	for rows.Next() {
+		var i TripInviteLink
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.Code,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.MaxUses,
+			&i.Uses,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTripLinks = `-- name: GetTripLinks :many
+SELECT
+    "id", "trip_id", "title", "url"
+FROM links
+WHERE
+    trip_id = $1
+`
+
+func (q *Queries) GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]Link, error) {
+	rows, err := q.db.Query(ctx, getTripLinks, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Link
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.Title,
+			&i.Url,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTripParticipantByEmail = `-- name: GetTripParticipantByEmail :one
+SELECT
+    "id", "trip_id", "email", "name", "confirmed_at", "role", "status", "invited_at", "last_invited_at", "invite_count", "waitlisted_at"
+FROM participants
+WHERE
+    trip_id = $1
+    AND lower("email") = lower($2)
+ORDER BY ("status" = 'confirmed') DESC
+LIMIT 1
+`
+
+type GetTripParticipantByEmailParams struct {
 	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
 	Email  string    `db:"email" json:"email"`
 }
 
+func (q *Queries) GetTripParticipantByEmail(ctx context.Context, arg GetTripParticipantByEmailParams) (Participant, error) {
+	row := q.db.QueryRow(ctx, getTripParticipantByEmail, arg.TripID, arg.Email)
+	var i Participant
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Email,
+		&i.Name,
+		&i.ConfirmedAt,
+		&i.Role,
+		&i.Status,
+		&i.InvitedAt,
+		&i.LastInvitedAt,
+		&i.InviteCount,
+		&i.WaitlistedAt,
+	)
+	return i, err
+}
+
+const insertActivityImport = `-- name: InsertActivityImport :exec
+INSERT INTO activity_imports
+    ( "activity_id", "trip_id", "uid" ) VALUES
+    ( $1, $2, $3 )
+`
+
+type InsertActivityImportParams struct {
+	ActivityID uuid.UUID `db:"activity_id" json:"activity_id"`
+	TripID     uuid.UUID `db:"trip_id" json:"trip_id"`
+	Uid        string    `db:"uid" json:"uid"`
+}
+
+func (q *Queries) InsertActivityImport(ctx context.Context, arg InsertActivityImportParams) error {
+	_, err := q.db.Exec(ctx, insertActivityImport, arg.ActivityID, arg.TripID, arg.Uid)
+	return err
+}
+
+const insertActivitySeries = `-- name: InsertActivitySeries :one
+INSERT INTO activity_series
+    ( "trip_id", "frequency", "interval", "weekdays", "until" ) VALUES
+    ( $1, $2, $3, $4, $5 )
+RETURNING "id"
+`
+
+type InsertActivitySeriesParams struct {
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Frequency string             `db:"frequency" json:"frequency"`
+	Interval  int32              `db:"interval" json:"interval"`
+	Weekdays  []string           `db:"weekdays" json:"weekdays"`
+	Until     pgtype.Timestamptz `db:"until" json:"until"`
+}
+
+func (q *Queries) InsertActivitySeries(ctx context.Context, arg InsertActivitySeriesParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, insertActivitySeries,
+		arg.TripID,
+		arg.Frequency,
+		arg.Interval,
+		arg.Weekdays,
+		arg.Until,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertParticipant = `-- name: InsertParticipant :one
+INSERT INTO participants
+    ( "trip_id", "email" ) VALUES
+    ( $1, $2 )
+ON CONFLICT ("trip_id", lower("email")) DO NOTHING
+RETURNING "id"
+`
+
+type InsertParticipantParams struct {
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+	Email  string    `db:"email" json:"email"`
+}
+
+func (q *Queries) InsertParticipant(ctx context.Context, arg InsertParticipantParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, insertParticipant, arg.TripID, arg.Email)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertTrip = `-- name: InsertTrip :one
+INSERT
+INTO trips
+    ( "destination", "owner_email", "owner_name", "starts_at", "ends_at", "timezone", "locale", "max_participants") VALUES
+    ( $1, $2, $3, $4, $5, $6, $7, $8 )
+RETURNING "id"
+`
+
+type InsertTripParams struct {
+	Destination     string             `db:"destination" json:"destination"`
+	OwnerEmail      string             `db:"owner_email" json:"owner_email"`
+	OwnerName       string             `db:"owner_name" json:"owner_name"`
+	StartsAt        pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	EndsAt          pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	Timezone        string             `db:"timezone" json:"timezone"`
+	Locale          string             `db:"locale" json:"locale"`
+	MaxParticipants pgtype.Int4        `db:"max_participants" json:"max_participants"`
+}
+
+func (q *Queries) InsertTrip(ctx context.Context, arg InsertTripParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, insertTrip,
+		arg.Destination,
+		arg.OwnerEmail,
+		arg.OwnerName,
+		arg.StartsAt,
+		arg.EndsAt,
+		arg.Timezone,
+		arg.Locale,
+		arg.MaxParticipants,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+type InviteParticipantsToTripParams struct {
+	TripID uuid.UUID   `db:"trip_id" json:"trip_id"`
+	Email  string      `db:"email" json:"email"`
+	Name   pgtype.Text `db:"name" json:"name"`
+}
+
+const listEmailOutbox = `-- name: ListEmailOutbox :many
+SELECT
+    "id", "kind", "trip_id", "participant_id", "status", "attempts", "last_error", "next_attempt_at", "created_at", "sent_at", "recipient"
+FROM email_outbox
+WHERE
+    "status" = $1
+ORDER BY
+    "created_at" DESC
+`
+
+func (q *Queries) ListEmailOutbox(ctx context.Context, status string) ([]EmailOutbox, error) {
+	rows, err := q.db.Query(ctx, listEmailOutbox, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EmailOutbox
+	for rows.Next() {
+		var i EmailOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.TripID,
+			&i.ParticipantID,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.SentAt,
+			&i.Recipient,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockTrip = `-- name: LockTrip :exec
+SELECT
+    "id"
+FROM trips
+WHERE
+    id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockTrip(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, lockTrip, id)
+	return err
+}
+
+const markEmailFailed = `-- name: MarkEmailFailed :exec
+UPDATE email_outbox
+SET
+    "status" = $1,
+    "attempts" = "attempts" + 1,
+    "last_error" = $2,
+    "next_attempt_at" = $3
+WHERE
+    id = $4
+`
+
+type MarkEmailFailedParams struct {
+	Status        string             `db:"status" json:"status"`
+	LastError     pgtype.Text        `db:"last_error" json:"last_error"`
+	NextAttemptAt pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	ID            uuid.UUID          `db:"id" json:"id"`
+}
+
+func (q *Queries) MarkEmailFailed(ctx context.Context, arg MarkEmailFailedParams) error {
+	_, err := q.db.Exec(ctx, markEmailFailed,
+		arg.Status,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.ID,
+	)
+	return err
+}
+
+const markEmailSent = `-- name: MarkEmailSent :exec
+UPDATE email_outbox
+SET
+    "status" = 'sent',
+    "attempts" = "attempts" + 1,
+    "last_error" = NULL,
+    "sent_at" = now()
+WHERE
+    id = $1
+`
+
+func (q *Queries) MarkEmailSent(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, markEmailSent, id)
+	return err
+}
+
+const markParticipantInvited = `-- name: MarkParticipantInvited :execrows
+UPDATE participants
+SET
+    "invited_at" = COALESCE("invited_at", now()),
+    "last_invited_at" = now(),
+    "invite_count" = "invite_count" + 1
+WHERE
+    id = $1
+    AND ("last_invited_at" IS NULL OR "last_invited_at" <= now() - $2::interval)
+`
+
+type MarkParticipantInvitedParams struct {
+	ID       uuid.UUID       `db:"id" json:"id"`
+	Cooldown pgtype.Interval `db:"cooldown" json:"cooldown"`
+}
+
+func (q *Queries) MarkParticipantInvited(ctx context.Context, arg MarkParticipantInvitedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markParticipantInvited, arg.ID, arg.Cooldown)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const promoteWaitlistedParticipants = `-- name: PromoteWaitlistedParticipants :many
+UPDATE participants
+SET
+    "status" = 'confirmed',
+    "confirmed_at" = now()
+WHERE
+    id IN (
+        SELECT "id"
+        FROM participants AS waitlist
+        WHERE
+            waitlist."trip_id" = $1
+            AND waitlist."status" = 'waitlisted'
+        ORDER BY waitlist."waitlisted_at", waitlist."id"
+        LIMIT $2
+        FOR UPDATE
+    )
+RETURNING "id"
+`
+
+type PromoteWaitlistedParticipantsParams struct {
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+	Limit  int32     `db:"limit" json:"limit"`
+}
+
+func (q *Queries) PromoteWaitlistedParticipants(ctx context.Context, arg PromoteWaitlistedParticipantsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, promoteWaitlistedParticipants, arg.TripID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retryEmail = `-- name: RetryEmail :execrows
+UPDATE email_outbox
+SET
+    "status" = 'pending',
+    "attempts" = 0,
+    "last_error" = NULL,
+    "next_attempt_at" = now()
+WHERE
+    id = $1
+    AND "status" = 'dead'
+`
+
+func (q *Queries) RetryEmail(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, retryEmail, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeTripInviteLink = `-- name: RevokeTripInviteLink :execrows
+UPDATE trip_invite_links
+SET
+    "revoked_at" = now()
+WHERE
+    id = $1
+    AND trip_id = $2
+    AND "revoked_at" IS NULL
+`
+
+type RevokeTripInviteLinkParams struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+}
+
+func (q *Queries) RevokeTripInviteLink(ctx context.Context, arg RevokeTripInviteLinkParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeTripInviteLink, arg.ID, arg.TripID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const shiftTripActivities = `-- name: ShiftTripActivities :exec
+UPDATE activities
+SET
+    "occurs_at" = "occurs_at" + $1::interval,
+    "ends_at" = "ends_at" + $1::interval
+WHERE
+    trip_id = $2
+`
+
+type ShiftTripActivitiesParams struct {
+	Shift  pgtype.Interval `db:"shift" json:"shift"`
+	TripID uuid.UUID       `db:"trip_id" json:"trip_id"`
+}
+
+func (q *Queries) ShiftTripActivities(ctx context.Context, arg ShiftTripActivitiesParams) error {
+	_, err := q.db.Exec(ctx, shiftTripActivities, arg.Shift, arg.TripID)
+	return err
+}
+
+const updateActivity = `-- name: UpdateActivity :exec
+UPDATE activities
+SET
+    "title" = $1,
+    "occurs_at" = $2,
+    "timezone" = $3,
+    "ends_at" = $4,
+    "location_name" = $5,
+    "latitude" = $6,
+    "longitude" = $7,
+    "notes" = $8,
+    "category" = $9,
+    "cost_amount" = $10,
+    "cost_currency" = $11
+WHERE
+    id = $12
+`
+
+type UpdateActivityParams struct {
+	Title        string             `db:"title" json:"title"`
+	OccursAt     pgtype.Timestamptz `db:"occurs_at" json:"occurs_at"`
+	Timezone     pgtype.Text        `db:"timezone" json:"timezone"`
+	EndsAt       pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	LocationName pgtype.Text        `db:"location_name" json:"location_name"`
+	Latitude     pgtype.Float8      `db:"latitude" json:"latitude"`
+	Longitude    pgtype.Float8      `db:"longitude" json:"longitude"`
+	Notes        pgtype.Text        `db:"notes" json:"notes"`
+	Category     string             `db:"category" json:"category"`
+	CostAmount   pgtype.Numeric     `db:"cost_amount" json:"cost_amount"`
+	CostCurrency pgtype.Text        `db:"cost_currency" json:"cost_currency"`
+	ID           uuid.UUID          `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateActivity(ctx context.Context, arg UpdateActivityParams) error {
+	_, err := q.db.Exec(ctx, updateActivity,
+		arg.Title,
+		arg.OccursAt,
+		arg.Timezone,
+		arg.EndsAt,
+		arg.LocationName,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Notes,
+		arg.Category,
+		arg.CostAmount,
+		arg.CostCurrency,
+		arg.ID,
+	)
+	return err
+}
+
+const updateActivitySeries = `-- name: UpdateActivitySeries :exec
+UPDATE activities
+SET
+    "title" = $1,
+    "occurs_at" = "occurs_at" + $2::interval,
+    "ends_at" = "occurs_at" + $2::interval + $3::interval,
+    "timezone" = $4,
+    "location_name" = $5,
+    "latitude" = $6,
+    "longitude" = $7,
+    "notes" = $8,
+    "category" = $9,
+    "cost_amount" = $10,
+    "cost_currency" = $11
+WHERE
+    series_id = $12::uuid
+`
+
+type UpdateActivitySeriesParams struct {
+	Title        string          `db:"title" json:"title"`
+	Shift        pgtype.Interval `db:"shift" json:"shift"`
+	Duration     pgtype.Interval `db:"duration" json:"duration"`
+	Timezone     pgtype.Text     `db:"timezone" json:"timezone"`
+	LocationName pgtype.Text     `db:"location_name" json:"location_name"`
+	Latitude     pgtype.Float8   `db:"latitude" json:"latitude"`
+	Longitude    pgtype.Float8   `db:"longitude" json:"longitude"`
+	Notes        pgtype.Text     `db:"notes" json:"notes"`
+	Category     string          `db:"category" json:"category"`
+	CostAmount   pgtype.Numeric  `db:"cost_amount" json:"cost_amount"`
+	CostCurrency pgtype.Text     `db:"cost_currency" json:"cost_currency"`
+	SeriesID     uuid.UUID       `db:"series_id" json:"series_id"`
+}
+
+func (q *Queries) UpdateActivitySeries(ctx context.Context, arg UpdateActivitySeriesParams) error {
+	_, err := q.db.Exec(ctx, updateActivitySeries,
+		arg.Title,
+		arg.Shift,
+		arg.Duration,
+		arg.Timezone,
+		arg.LocationName,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Notes,
+		arg.Category,
+		arg.CostAmount,
+		arg.CostCurrency,
+		arg.SeriesID,
+	)
+	return err
+}
+
+const updateParticipantRole = `-- name: UpdateParticipantRole :exec
+UPDATE participants
+SET
+    "role" = $1
+WHERE
+    id = $2
+`
+
+type UpdateParticipantRoleParams struct {
+	Role string    `db:"role" json:"role"`
+	ID   uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateParticipantRole(ctx context.Context, arg UpdateParticipantRoleParams) error {
+	_, err := q.db.Exec(ctx, updateParticipantRole, arg.Role, arg.ID)
+	return err
+}
+
+const updateParticipantStatus = `-- name: UpdateParticipantStatus :exec
+UPDATE participants
+SET
+    "status" = $1
+WHERE
+    id = $2
+`
+
+type UpdateParticipantStatusParams struct {
+	Status string    `db:"status" json:"status"`
+	ID     uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateParticipantStatus(ctx context.Context, arg UpdateParticipantStatusParams) error {
+	_, err := q.db.Exec(ctx, updateParticipantStatus, arg.Status, arg.ID)
+	return err
+}
+
 const updateTrip = `-- name: UpdateTrip :exec
 UPDATE trips
 SET
     "destination" = $1,
     "ends_at" = $2,
     "starts_at" = $3,
-    "is_confirmed" = $4
+    "is_confirmed" = $4,
+    "timezone" = $5,
+    "max_participants" = $6
 WHERE
-    id = $5
+    id = $7
 `
 
 type UpdateTripParams struct {
-	Destination string           `db:"destination" json:"destination"`
-	EndsAt      pgtype.Timestamp `db:"ends_at" json:"ends_at"`
-	StartsAt    pgtype.Timestamp `db:"starts_at" json:"starts_at"`
-	IsConfirmed bool             `db:"is_confirmed" json:"is_confirmed"`
-	ID          uuid.UUID        `db:"id" json:"id"`
+	Destination     string             `db:"destination" json:"destination"`
+	EndsAt          pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	StartsAt        pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	IsConfirmed     bool               `db:"is_confirmed" json:"is_confirmed"`
+	Timezone        string             `db:"timezone" json:"timezone"`
+	MaxParticipants pgtype.Int4        `db:"max_participants" json:"max_participants"`
+	ID              uuid.UUID          `db:"id" json:"id"`
 }
 
 func (q *Queries) UpdateTrip(ctx context.Context, arg UpdateTripParams) error {
@@ -266,7 +1413,88 @@ func (q *Queries) UpdateTrip(ctx context.Context, arg UpdateTripParams) error {
 		arg.EndsAt,
 		arg.StartsAt,
 		arg.IsConfirmed,
+		arg.Timezone,
+		arg.MaxParticipants,
 		arg.ID,
 	)
 	return err
 }
+
+const updateTripOwner = `-- name: UpdateTripOwner :exec
+UPDATE trips
+SET
+    "owner_email" = $1,
+    "owner_name" = $2
+WHERE
+    id = $3
+`
+
+type UpdateTripOwnerParams struct {
+	OwnerEmail string    `db:"owner_email" json:"owner_email"`
+	OwnerName  string    `db:"owner_name" json:"owner_name"`
+	ID         uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateTripOwner(ctx context.Context, arg UpdateTripOwnerParams) error {
+	_, err := q.db.Exec(ctx, updateTripOwner, arg.OwnerEmail, arg.OwnerName, arg.ID)
+	return err
+}
+
+const useToken = `-- name: UseToken :execrows
+INSERT INTO used_tokens
+    ( "id", "expires_at" ) VALUES
+    ( $1, $2 )
+ON CONFLICT ("id") DO NOTHING
+`
+
+type UseTokenParams struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) UseToken(ctx context.Context, arg UseTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useToken, arg.ID, arg.ExpiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useTripInviteLink = `-- name: UseTripInviteLink :execrows
+UPDATE trip_invite_links
+SET
+    "uses" = "uses" + 1
+WHERE
+    id = $1
+    AND "revoked_at" IS NULL
+    AND ("expires_at" IS NULL OR "expires_at" > now())
+    AND ("max_uses" IS NULL OR "uses" < "max_uses")
+`
+
+func (q *Queries) UseTripInviteLink(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, useTripInviteLink, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const waitlistParticipant = `-- name: WaitlistParticipant :exec
+UPDATE participants
+SET
+    "status" = 'waitlisted',
+    "name" = $1,
+    "waitlisted_at" = now()
+WHERE
+    id = $2
+`
+
+type WaitlistParticipantParams struct {
+	Name pgtype.Text `db:"name" json:"name"`
+	ID   uuid.UUID   `db:"id" json:"id"`
+}
+
+func (q *Queries) WaitlistParticipant(ctx context.Context, arg WaitlistParticipantParams) error {
+	_, err := q.db.Exec(ctx, waitlistParticipant, arg.Name, arg.ID)
+	return err
+}
@@ -1,67 +1,312 @@
 -- name: InsertTrip :one
 INSERT
 INTO trips
-    ( "destination", "owner_email", "owner_name", "starts_at", "ends_at") VALUES
-    ( $1, $2, $3, $4, $5 )
+    ( "destination", "owner_email", "owner_name", "starts_at", "ends_at", "timezone", "locale", "max_participants") VALUES
+    ( $1, $2, $3, $4, $5, $6, $7, $8 )
 RETURNING "id";
 
 -- name: GetTrip :one
 SELECT
-    "id", "destination", "owner_email", "owner_name", "is_confirmed", "starts_at", "ends_at"
+    "id", "destination", "owner_email", "owner_name", "is_confirmed", "starts_at", "ends_at", "timezone", "locale", "max_participants"
 FROM trips
 WHERE
     id = $1;
 
+-- name: GetTripCapacityForUpdate :one
+SELECT
+    "max_participants"
+FROM trips
+WHERE
+    id = $1
+FOR UPDATE;
+
+-- name: LockTrip :exec
+SELECT
+    "id"
+FROM trips
+WHERE
+    id = $1
+FOR UPDATE;
+
 -- name: UpdateTrip :exec
 UPDATE trips
 SET
     "destination" = $1,
     "ends_at" = $2,
     "starts_at" = $3,
-    "is_confirmed" = $4
+    "is_confirmed" = $4,
+    "timezone" = $5,
+    "max_participants" = $6
+WHERE
+    id = $7;
+
+-- name: UpdateTripOwner :exec
+UPDATE trips
+SET
+    "owner_email" = $1,
+    "owner_name" = $2
+WHERE
+    id = $3;
+
+-- name: ConfirmTrip :exec
+UPDATE trips
+SET
+    "is_confirmed" = true
 WHERE
-    id = $5;
+    id = $1;
 
 -- name: GetParticipant :one
 SELECT
-    "id", "trip_id", "email", "is_confirmed"
+    "id", "trip_id", "email", "name", "confirmed_at", "role", "status", "invited_at", "last_invited_at", "invite_count", "waitlisted_at"
 FROM participants
 WHERE
     id = $1;
 
 -- name: ConfirmParticipant :exec
+UPDATE participants
+SET
+    "status" = 'confirmed',
+    "name" = COALESCE(sqlc.narg(name), "name"),
+    "confirmed_at" = now()
+WHERE
+    id = $2;
+
+-- name: WaitlistParticipant :exec
+UPDATE participants
+SET
+    "status" = 'waitlisted',
+    "name" = $1,
+    "waitlisted_at" = now()
+WHERE
+    id = $2;
+
+-- name: PromoteWaitlistedParticipants :many
+UPDATE participants
+SET
+    "status" = 'confirmed',
+    "confirmed_at" = now()
+WHERE
+    id IN (
+        SELECT "id"
+        FROM participants AS waitlist
+        WHERE
+            waitlist."trip_id" = $1
+            AND waitlist."status" = 'waitlisted'
+        ORDER BY waitlist."waitlisted_at", waitlist."id"
+        LIMIT $2
+        FOR UPDATE
+    )
+RETURNING "id";
+
+-- name: CountConfirmedParticipants :one
 SELECT
-    "id", "trip_id", "email", "is_confirmed"
+    count(*)
 FROM participants
 WHERE
-    id = $1;
-
+    trip_id = $1
+    AND "status" = 'confirmed';
 
 -- name: GetParticipants :many
 SELECT
-    "id", "trip_id", "email", "is_confirmed"
+    "id", "trip_id", "email", "name", "confirmed_at", "role", "status", "invited_at", "last_invited_at", "invite_count", "waitlisted_at"
 FROM participants
 WHERE
-    trip_id = $1;
+    trip_id = sqlc.arg(trip_id)
+    AND (sqlc.narg(status)::text IS NULL OR "status" = sqlc.narg(status));
 
--- name: InviteParticipantsToTrip :copyfrom
+-- name: GetTripParticipantByEmail :one
+SELECT
+    "id", "trip_id", "email", "name", "confirmed_at", "role", "status", "invited_at", "last_invited_at", "invite_count", "waitlisted_at"
+FROM participants
+WHERE
+    trip_id = $1
+    AND lower("email") = lower(sqlc.arg(email))
+ORDER BY ("status" = 'confirmed') DESC
+LIMIT 1;
+
+-- name: InsertParticipant :one
 INSERT INTO participants
     ( "trip_id", "email" ) VALUES
-    ( $1, $2 );
+    ( $1, $2 )
+ON CONFLICT ("trip_id", lower("email")) DO NOTHING
+RETURNING "id";
+
+-- name: MarkParticipantInvited :execrows
+UPDATE participants
+SET
+    "invited_at" = COALESCE("invited_at", now()),
+    "last_invited_at" = now(),
+    "invite_count" = "invite_count" + 1
+WHERE
+    id = sqlc.arg(id)
+    AND ("last_invited_at" IS NULL OR "last_invited_at" <= now() - sqlc.arg(cooldown)::interval);
+
+-- name: UpdateParticipantStatus :exec
+UPDATE participants
+SET
+    "status" = $1
+WHERE
+    id = $2;
+
+-- name: UpdateParticipantRole :exec
+UPDATE participants
+SET
+    "role" = $1
+WHERE
+    id = $2;
+
+-- name: DeleteParticipant :exec
+DELETE FROM participants
+WHERE
+    id = $1;
+
+-- name: InviteParticipantsToTrip :copyfrom
+INSERT INTO participants
+    ( "trip_id", "email", "name" ) VALUES
+    ( $1, $2, $3 );
 
 -- name: CreateActivity :one
 INSERT INTO activities
-    ( "trip_id", "title", "occurs_at" ) VALUES
-    ( $1, $2, $3 )
+    ( "trip_id", "title", "occurs_at", "timezone", "ends_at", "location_name", "latitude", "longitude", "notes", "category", "cost_amount", "cost_currency", "series_id" ) VALUES
+    ( $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13 )
 RETURNING "id";
 
+-- name: GetActivity :one
+SELECT
+    "id", "trip_id", "title", "occurs_at", "timezone", "ends_at", "location_name", "latitude", "longitude", "notes", "category", "cost_amount", "cost_currency", "series_id"
+FROM activities
+WHERE
+    id = $1;
+
+-- name: UpdateActivity :exec
+UPDATE activities
+SET
+    "title" = $1,
+    "occurs_at" = $2,
+    "timezone" = $3,
+    "ends_at" = $4,
+    "location_name" = $5,
+    "latitude" = $6,
+    "longitude" = $7,
+    "notes" = $8,
+    "category" = $9,
+    "cost_amount" = $10,
+    "cost_currency" = $11
+WHERE
+    id = $12;
+
+-- name: DeleteActivity :exec
+DELETE FROM activities
+WHERE
+    id = $1;
+
 -- name: GetTripActivities :many
 SELECT
-    "id", "trip_id", "title", "occurs_at"
+    "id", "trip_id", "title", "occurs_at", "timezone", "ends_at", "location_name", "latitude", "longitude", "notes", "category", "cost_amount", "cost_currency", "series_id"
 FROM activities
+WHERE
+    trip_id = sqlc.arg(trip_id)
+    AND (sqlc.narg(category)::text IS NULL OR "category" = sqlc.narg(category))
+ORDER BY
+    "occurs_at";
+
+-- name: ShiftTripActivities :exec
+UPDATE activities
+SET
+    "occurs_at" = "occurs_at" + sqlc.arg(shift)::interval,
+    "ends_at" = "ends_at" + sqlc.arg(shift)::interval
+WHERE
+    trip_id = sqlc.arg(trip_id);
+
+-- name: GetTripActivitiesOutsideRange :many
+SELECT
+    "id", "trip_id", "title", "occurs_at", "timezone", "ends_at", "location_name", "latitude", "longitude", "notes", "category", "cost_amount", "cost_currency", "series_id"
+FROM activities
+WHERE
+    trip_id = sqlc.arg(trip_id)
+    AND ("occurs_at" < sqlc.arg(starts_at) OR COALESCE("ends_at", "occurs_at") > sqlc.arg(ends_at))
+ORDER BY
+    "occurs_at";
+
+-- name: GetActivityOverlaps :many
+SELECT
+    b."id", b."trip_id", b."title", b."occurs_at", b."timezone", b."ends_at", b."location_name", b."latitude", b."longitude", b."notes", b."category", b."cost_amount", b."cost_currency", b."series_id"
+FROM activities a
+JOIN activities b
+    ON b."trip_id" = a."trip_id"
+    AND b."id" <> a."id"
+    AND activity_period(b."occurs_at", b."ends_at") && activity_period(a."occurs_at", a."ends_at")
+WHERE
+    a."id" = $1
+ORDER BY
+    b."occurs_at";
+
+-- name: GetTripActivityOverlaps :many
+SELECT
+    a."id" AS "first_id", b."id" AS "second_id"
+FROM activities a
+JOIN activities b
+    ON b."trip_id" = a."trip_id"
+    AND (a."occurs_at", a."id") < (b."occurs_at", b."id")
+    AND activity_period(a."occurs_at", a."ends_at") && activity_period(b."occurs_at", b."ends_at")
+WHERE
+    a."trip_id" = $1
+ORDER BY
+    a."occurs_at", b."occurs_at";
+
+-- name: InsertActivitySeries :one
+INSERT INTO activity_series
+    ( "trip_id", "frequency", "interval", "weekdays", "until" ) VALUES
+    ( $1, $2, $3, $4, $5 )
+RETURNING "id";
+
+-- name: UpdateActivitySeries :exec
+UPDATE activities
+SET
+    "title" = sqlc.arg(title),
+    "occurs_at" = "occurs_at" + sqlc.arg(shift)::interval,
+    "ends_at" = "occurs_at" + sqlc.arg(shift)::interval + sqlc.narg(duration)::interval,
+    "timezone" = sqlc.narg(timezone),
+    "location_name" = sqlc.narg(location_name),
+    "latitude" = sqlc.narg(latitude),
+    "longitude" = sqlc.narg(longitude),
+    "notes" = sqlc.narg(notes),
+    "category" = sqlc.arg(category),
+    "cost_amount" = sqlc.narg(cost_amount),
+    "cost_currency" = sqlc.narg(cost_currency)
+WHERE
+    series_id = sqlc.arg(series_id)::uuid;
+
+-- name: DeleteActivitySeries :exec
+DELETE FROM activity_series
+WHERE
+    id = $1;
+
+-- name: GetActivitySeriesOverlaps :many
+SELECT DISTINCT
+    b."id", b."trip_id", b."title", b."occurs_at", b."timezone", b."ends_at", b."location_name", b."latitude", b."longitude", b."notes", b."category", b."cost_amount", b."cost_currency", b."series_id"
+FROM activities a
+JOIN activities b
+    ON b."trip_id" = a."trip_id"
+    AND b."series_id" IS DISTINCT FROM a."series_id"
+    AND activity_period(b."occurs_at", b."ends_at") && activity_period(a."occurs_at", a."ends_at")
+WHERE
+    a."series_id" = sqlc.arg(series_id)::uuid
+ORDER BY
+    b."occurs_at";
+
+-- name: GetTripImportedUIDs :many
+SELECT
+    "uid"
+FROM activity_imports
 WHERE
     trip_id = $1;
 
+-- name: InsertActivityImport :exec
+INSERT INTO activity_imports
+    ( "activity_id", "trip_id", "uid" ) VALUES
+    ( $1, $2, $3 );
+
 -- name: CreateTripLink :one
 INSERT INTO links
     ( "trip_id", "title", "url" ) VALUES
@@ -74,3 +319,146 @@ SELECT
 FROM links
 WHERE
     trip_id = $1;
+
+-- name: CreateTripInviteLink :one
+INSERT INTO trip_invite_links
+    ( "trip_id", "code", "created_by", "expires_at", "max_uses" ) VALUES
+    ( $1, $2, $3, $4, $5 )
+RETURNING "id", "trip_id", "code", "created_by", "created_at", "expires_at", "max_uses", "uses", "revoked_at";
+
+-- name: GetTripInviteLinks :many
+SELECT
+    "id", "trip_id", "code", "created_by", "created_at", "expires_at", "max_uses", "uses", "revoked_at"
+FROM trip_invite_links
+WHERE
+    trip_id = $1
+ORDER BY
+    "created_at" DESC;
+
+-- name: GetTripInviteLinkByCode :one
+SELECT
+    "id", "trip_id", "code", "created_by", "created_at", "expires_at", "max_uses", "uses", "revoked_at"
+FROM trip_invite_links
+WHERE
+    code = $1;
+
+-- name: RevokeTripInviteLink :execrows
+UPDATE trip_invite_links
+SET
+    "revoked_at" = now()
+WHERE
+    id = $1
+    AND trip_id = $2
+    AND "revoked_at" IS NULL;
+
+-- name: UseTripInviteLink :execrows
+UPDATE trip_invite_links
+SET
+    "uses" = "uses" + 1
+WHERE
+    id = $1
+    AND "revoked_at" IS NULL
+    AND ("expires_at" IS NULL OR "expires_at" > now())
+    AND ("max_uses" IS NULL OR "uses" < "max_uses");
+
+-- name: EnqueueEmail :exec
+INSERT INTO email_outbox
+    ( "kind", "trip_id", "participant_id", "recipient" ) VALUES
+    ( $1, $2, $3, $4 );
+
+-- name: GetLastLoginEmailAt :one
+SELECT
+    "created_at"
+FROM email_outbox
+WHERE
+    "kind" = 'login'
+    AND "recipient" = $1
+ORDER BY
+    "created_at" DESC
+LIMIT 1;
+
+-- name: ClaimPendingEmails :many
+UPDATE email_outbox
+SET
+    "next_attempt_at" = now() + sqlc.arg(lease)::interval
+WHERE
+    id IN (
+        SELECT "id"
+        FROM email_outbox
+        WHERE
+            "status" = 'pending'
+            AND "next_attempt_at" <= now()
+        ORDER BY "next_attempt_at"
+        LIMIT sqlc.arg(max_emails)
+        FOR UPDATE SKIP LOCKED
+    )
+RETURNING
+    "id", "kind", "trip_id", "participant_id", "status", "attempts", "last_error", "next_attempt_at", "created_at", "sent_at", "recipient";
+
+-- name: MarkEmailSent :exec
+UPDATE email_outbox
+SET
+    "status" = 'sent',
+    "attempts" = "attempts" + 1,
+    "last_error" = NULL,
+    "sent_at" = now()
+WHERE
+    id = $1;
+
+-- name: MarkEmailFailed :exec
+UPDATE email_outbox
+SET
+    "status" = sqlc.arg(status),
+    "attempts" = "attempts" + 1,
+    "last_error" = sqlc.arg(last_error),
+    "next_attempt_at" = sqlc.arg(next_attempt_at)
+WHERE
+    id = sqlc.arg(id);
+
+-- name: ListEmailOutbox :many
+SELECT
+    "id", "kind", "trip_id", "participant_id", "status", "attempts", "last_error", "next_attempt_at", "created_at", "sent_at", "recipient"
+FROM email_outbox
+WHERE
+    "status" = $1
+ORDER BY
+    "created_at" DESC;
+
+-- name: RetryEmail :execrows
+UPDATE email_outbox
+SET
+    "status" = 'pending',
+    "attempts" = 0,
+    "last_error" = NULL,
+    "next_attempt_at" = now()
+WHERE
+    id = $1
+    AND "status" = 'dead';
+
+-- name: UseToken :execrows
+INSERT INTO used_tokens
+    ( "id", "expires_at" ) VALUES
+    ( $1, $2 )
+ON CONFLICT ("id") DO NOTHING;
+
+-- name: CreateCalendarFeed :one
+INSERT INTO calendar_feeds
+    ( "trip_id", "email", "token" ) VALUES
+    ( $1, $2, $3 )
+ON CONFLICT ("trip_id", lower("email")) DO UPDATE
+SET
+    "email" = EXCLUDED."email"
+RETURNING "id", "trip_id", "email", "token", "created_at";
+
+-- name: GetCalendarFeedByToken :one
+SELECT
+    "id", "trip_id", "email", "token", "created_at"
+FROM calendar_feeds
+WHERE
+    token = $1;
+
+-- name: DeleteCalendarFeed :execrows
+DELETE FROM calendar_feeds
+WHERE
+    trip_id = $1
+    AND lower("email") = lower(sqlc.arg(email));
@@ -2,14 +2,52 @@ package pgstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"journey/internal/api/spec"
+	"math"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// ErrActivitiesOutsideTrip is returned by RescheduleTrip and
+// RescheduleActivitySeries when, after the update, some of the trip activities
+// would fall outside its dates.
+var ErrActivitiesOutsideTrip = errors.New("pgstore: activities outside of the trip dates")
+
+// ErrActivityOverlaps is returned by the activity transactions, in strict
+// mode, when the activities would overlap others of the same trip.
+var ErrActivityOverlaps = errors.New("pgstore: activity overlaps other activities of the trip")
+
+// ErrActivityImported is returned by ImportActivities when one of the events
+// was imported into the trip by another request while it ran.
+var ErrActivityImported = errors.New("pgstore: calendar event already imported")
+
+// uniqueViolation is the Postgres error code for a unique constraint
+// violation.
+const uniqueViolation = "23505"
+
+// ErrInviteConflict is returned by InviteParticipantsInBulk and JoinTrip when
+// one of the e-mails was added to the trip by another request while it ran.
+var ErrInviteConflict = errors.New("pgstore: participant invited concurrently")
+
+// ErrInviteCooldown is returned by ResendInvite when the participant was
+// invited too recently.
+var ErrInviteCooldown = errors.New("pgstore: participant invited too recently")
+
+// ErrInviteLinkUnusable is returned by JoinTrip when the invite link was
+// revoked, expired or reached its maximum uses.
+var ErrInviteLinkUnusable = errors.New("pgstore: invite link can't be used")
+
+// ErrTokenUsed is returned by the confirmation transactions when their token
+// was already used.
+var ErrTokenUsed = errors.New("pgstore: token already used")
+
 func (q *Queries) CreateTrip(
 	ctx context.Context,
 	pool *pgxpool.Pool,
@@ -23,12 +61,30 @@ func (q *Queries) CreateTrip(
 
 	qtx := q.WithTx(tx)
 
+	timezone := DefaultTimezone
+	if params.Timezone != nil {
+		timezone = *params.Timezone
+	}
+
+	locale := DefaultLocale
+	if params.Locale != nil {
+		locale = *params.Locale
+	}
+
+	var maxParticipants pgtype.Int4
+	if params.MaxParticipants != nil {
+		maxParticipants = pgtype.Int4{Valid: true, Int32: int32(*params.MaxParticipants)}
+	}
+
 	tripID, err := qtx.InsertTrip(ctx, InsertTripParams{
-		Destination: params.Destination,
-		OwnerEmail:  string(params.OwnerEmail),
-		OwnerName:   params.OwnerName,
-		StartsAt:    pgtype.Timestamp{Valid: true, Time: params.StartsAt},
-		EndsAt:      pgtype.Timestamp{Valid: true, Time: params.EndsAt},
+		Destination:     params.Destination,
+		OwnerEmail:      NormalizeEmail(string(params.OwnerEmail)),
+		OwnerName:       params.OwnerName,
+		StartsAt:        pgtype.Timestamptz{Valid: true, Time: params.StartsAt},
+		EndsAt:          pgtype.Timestamptz{Valid: true, Time: params.EndsAt},
+		Timezone:        timezone,
+		Locale:          locale,
+		MaxParticipants: maxParticipants,
 	})
 
 	if err != nil {
@@ -39,7 +95,7 @@ func (q *Queries) CreateTrip(
 	for i, eti := range params.EmailsToInvite {
 		participants[i] = InviteParticipantsToTripParams{
 			TripID: tripID,
-			Email:  string(eti),
+			Email:  NormalizeEmail(string(eti)),
 		}
 	}
 
@@ -47,9 +103,950 @@ func (q *Queries) CreateTrip(
 		return uuid.UUID{}, fmt.Errorf("pgstore: failed to insert participants for CreateTrip: %w", err)
 	}
 
+	if err := qtx.EnqueueEmail(ctx, EnqueueEmailParams{
+		Kind:   EmailKindConfirmTrip,
+		TripID: pgtype.UUID{Valid: true, Bytes: tripID},
+	}); err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to enqueue email for CreateTrip: %w", err)
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return uuid.UUID{}, fmt.Errorf("pgstore: failed to commit tx for CreateTrip: %w", err)
 	}
 
 	return tripID, nil
 }
+
+// InviteParticipants adds each of emails to the trip, in a single
+// transaction. Addresses already on the trip, whatever their status, and the
+// owner address are left alone and reported in the results.
+func (q *Queries) InviteParticipants(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	trip Trip,
+	emails []string,
+) ([]InviteResult, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to begin trx for InviteParticipants: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	results := make([]InviteResult, 0, len(emails))
+	for _, email := range emails {
+		email = NormalizeEmail(email)
+
+		if email == NormalizeEmail(trip.OwnerEmail) {
+			results = append(results, InviteResult{Email: email, Outcome: InviteOutcomeOwner})
+			continue
+		}
+
+		participantID, err := qtx.InsertParticipant(ctx, InsertParticipantParams{
+			TripID: trip.ID,
+			Email:  email,
+		})
+		if err == nil {
+			if trip.IsConfirmed {
+				if err := qtx.enqueueInvite(ctx, trip.ID, participantID, 0); err != nil {
+					return nil, fmt.Errorf("pgstore: failed to enqueue invite for InviteParticipants: %w", err)
+				}
+			}
+
+			results = append(results, InviteResult{
+				Email:         email,
+				Outcome:       InviteOutcomeCreated,
+				ParticipantID: participantID,
+			})
+			continue
+		}
+
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("pgstore: failed to insert participant for InviteParticipants: %w", err)
+		}
+
+		existing, err := qtx.GetTripParticipantByEmail(ctx, GetTripParticipantByEmailParams{
+			TripID: trip.ID,
+			Email:  email,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("pgstore: failed to get participant for InviteParticipants: %w", err)
+		}
+
+		results = append(results, InviteResult{
+			Email:         email,
+			Outcome:       InviteOutcomeAlreadyInvited,
+			ParticipantID: existing.ID,
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to commit tx for InviteParticipants: %w", err)
+	}
+
+	return results, nil
+}
+
+// InviteParticipantsInBulk adds the new e-mails among invites to the trip
+// with a single COPY, in one transaction. Like InviteParticipants, addresses
+// already on the trip and the owner address are only reported in the results,
+// which follow the order of invites.
+func (q *Queries) InviteParticipantsInBulk(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	trip Trip,
+	invites []InviteParticipantsToTripParams,
+) ([]InviteResult, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to begin trx for InviteParticipantsInBulk: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	results, err := qtx.copyParticipants(ctx, trip, invites)
+	if err != nil {
+		if errors.Is(err, ErrInviteConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("pgstore: failed to copy participants for InviteParticipantsInBulk: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to commit tx for InviteParticipantsInBulk: %w", err)
+	}
+
+	return results, nil
+}
+
+// JoinTrip adds the participant in invite to the trip through one of its
+// invite links. A use of the link is only counted when the participant is
+// new; ErrInviteLinkUnusable is returned when the link can't be used anymore.
+func (q *Queries) JoinTrip(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	trip Trip,
+	link TripInviteLink,
+	invite InviteParticipantsToTripParams,
+) (InviteResult, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return InviteResult{}, fmt.Errorf("pgstore: failed to begin trx for JoinTrip: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	results, err := qtx.copyParticipants(ctx, trip, []InviteParticipantsToTripParams{invite})
+	if err != nil {
+		if errors.Is(err, ErrInviteConflict) {
+			return InviteResult{}, err
+		}
+		return InviteResult{}, fmt.Errorf("pgstore: failed to copy participant for JoinTrip: %w", err)
+	}
+
+	result := results[0]
+	if result.Outcome == InviteOutcomeCreated {
+		used, err := qtx.UseTripInviteLink(ctx, link.ID)
+		if err != nil {
+			return InviteResult{}, fmt.Errorf("pgstore: failed to use invite link for JoinTrip: %w", err)
+		}
+
+		if used == 0 {
+			return InviteResult{}, ErrInviteLinkUnusable
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return InviteResult{}, fmt.Errorf("pgstore: failed to commit tx for JoinTrip: %w", err)
+	}
+
+	return result, nil
+}
+
+// ConfirmTripAndInviteParticipants confirms the trip and enqueues an
+// invitation e-mail for each of its invited participants. The token is marked as used
+// in the same transaction, so it can only confirm the trip once.
+func (q *Queries) ConfirmTripAndInviteParticipants(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	tripID uuid.UUID,
+	token UseTokenParams,
+) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin trx for ConfirmTripAndInviteParticipants: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.useToken(ctx, token); err != nil {
+		return fmt.Errorf("pgstore: failed to use token for ConfirmTripAndInviteParticipants: %w", err)
+	}
+
+	if err := qtx.ConfirmTrip(ctx, tripID); err != nil {
+		return fmt.Errorf("pgstore: failed to confirm trip for ConfirmTripAndInviteParticipants: %w", err)
+	}
+
+	participants, err := qtx.GetParticipants(ctx, GetParticipantsParams{
+		TripID: tripID,
+		Status: pgtype.Text{Valid: true, String: ParticipantStatusInvited},
+	})
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to get participants for ConfirmTripAndInviteParticipants: %w", err)
+	}
+
+	for _, participant := range participants {
+		if err := qtx.enqueueInvite(ctx, tripID, participant.ID, 0); err != nil {
+			return fmt.Errorf("pgstore: failed to enqueue invite for ConfirmTripAndInviteParticipants: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgstore: failed to commit tx for ConfirmTripAndInviteParticipants: %w", err)
+	}
+
+	return nil
+}
+
+// ResendInvite enqueues the invitation e-mail of the participant again,
+// unless they were last invited less than cooldown ago, in which case
+// ErrInviteCooldown is returned.
+func (q *Queries) ResendInvite(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	tripID uuid.UUID,
+	participantID uuid.UUID,
+	cooldown time.Duration,
+) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin trx for ResendInvite: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.enqueueInvite(ctx, tripID, participantID, cooldown); err != nil {
+		if errors.Is(err, ErrInviteCooldown) {
+			return err
+		}
+		return fmt.Errorf("pgstore: failed to enqueue invite for ResendInvite: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgstore: failed to commit tx for ResendInvite: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmParticipantWithToken confirms the participant and marks the token as
+// used in a single transaction. When the trip has no seat left the
+// participant is put on its waitlist instead; the returned status tells which
+// one happened.
+func (q *Queries) ConfirmParticipantWithToken(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	tripID uuid.UUID,
+	params ConfirmParticipantParams,
+	token UseTokenParams,
+) (string, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("pgstore: failed to begin trx for ConfirmParticipantWithToken: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.useToken(ctx, token); err != nil {
+		return "", fmt.Errorf("pgstore: failed to use token for ConfirmParticipantWithToken: %w", err)
+	}
+
+	seats, err := qtx.seatsLeft(ctx, tripID)
+	if err != nil {
+		return "", fmt.Errorf("pgstore: failed to count seats for ConfirmParticipantWithToken: %w", err)
+	}
+
+	status := ParticipantStatusConfirmed
+	if seats > 0 {
+		err = qtx.ConfirmParticipant(ctx, params)
+	} else {
+		status = ParticipantStatusWaitlisted
+		err = qtx.WaitlistParticipant(ctx, WaitlistParticipantParams{
+			Name: params.Name,
+			ID:   params.ID,
+		})
+	}
+	if err != nil {
+		return "", fmt.Errorf("pgstore: failed to confirm participant for ConfirmParticipantWithToken: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("pgstore: failed to commit tx for ConfirmParticipantWithToken: %w", err)
+	}
+
+	return status, nil
+}
+
+// DeclineParticipantWithToken marks the participant as declined and the token
+// as used in a single transaction. A seat freed this way goes to the first
+// participant on the waitlist.
+func (q *Queries) DeclineParticipantWithToken(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	tripID uuid.UUID,
+	participantID uuid.UUID,
+	token UseTokenParams,
+) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin trx for DeclineParticipantWithToken: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.useToken(ctx, token); err != nil {
+		return fmt.Errorf("pgstore: failed to use token for DeclineParticipantWithToken: %w", err)
+	}
+
+	if err := qtx.UpdateParticipantStatus(ctx, UpdateParticipantStatusParams{
+		Status: ParticipantStatusDeclined,
+		ID:     participantID,
+	}); err != nil {
+		return fmt.Errorf("pgstore: failed to decline participant for DeclineParticipantWithToken: %w", err)
+	}
+
+	if err := qtx.fillSeats(ctx, tripID); err != nil {
+		return fmt.Errorf("pgstore: failed to promote waitlist for DeclineParticipantWithToken: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgstore: failed to commit tx for DeclineParticipantWithToken: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseParticipant sets the participant status to declined or removed and,
+// in the same transaction, gives the seat they may have freed to the first
+// participant on the waitlist.
+func (q *Queries) ReleaseParticipant(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	tripID uuid.UUID,
+	participantID uuid.UUID,
+	status string,
+) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin trx for ReleaseParticipant: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.UpdateParticipantStatus(ctx, UpdateParticipantStatusParams{
+		Status: status,
+		ID:     participantID,
+	}); err != nil {
+		return fmt.Errorf("pgstore: failed to update participant for ReleaseParticipant: %w", err)
+	}
+
+	if err := qtx.fillSeats(ctx, tripID); err != nil {
+		return fmt.Errorf("pgstore: failed to promote waitlist for ReleaseParticipant: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgstore: failed to commit tx for ReleaseParticipant: %w", err)
+	}
+
+	return nil
+}
+
+// TransferTripOwnership makes newOwner the owner of the trip. Their
+// participant row is removed, and the previous owner becomes a co-organizer:
+// their own participant row is reused when they have one, and they take a seat
+// only when the trip has one left, like any other confirmation, or are
+// waitlisted otherwise.
+func (q *Queries) TransferTripOwnership(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	trip Trip,
+	newOwner Participant,
+) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin trx for TransferTripOwnership: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.LockTrip(ctx, trip.ID); err != nil {
+		return fmt.Errorf("pgstore: failed to lock trip for TransferTripOwnership: %w", err)
+	}
+
+	ownerName := newOwner.Name.String
+	if !newOwner.Name.Valid || ownerName == "" {
+		ownerName = newOwner.Email
+	}
+
+	if err := qtx.UpdateTripOwner(ctx, UpdateTripOwnerParams{
+		OwnerEmail: newOwner.Email,
+		OwnerName:  ownerName,
+		ID:         trip.ID,
+	}); err != nil {
+		return fmt.Errorf("pgstore: failed to update owner for TransferTripOwnership: %w", err)
+	}
+
+	if err := qtx.DeleteParticipant(ctx, newOwner.ID); err != nil {
+		return fmt.Errorf("pgstore: failed to delete participant for TransferTripOwnership: %w", err)
+	}
+
+	// The seat of the new owner, when they had one, is now free.
+	seats, err := qtx.seatsLeft(ctx, trip.ID)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to count seats for TransferTripOwnership: %w", err)
+	}
+
+	previous, err := qtx.GetTripParticipantByEmail(ctx, GetTripParticipantByEmailParams{
+		TripID: trip.ID,
+		Email:  trip.OwnerEmail,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		previous.ID, err = qtx.InsertParticipant(ctx, InsertParticipantParams{
+			TripID: trip.ID,
+			Email:  trip.OwnerEmail,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to get previous owner for TransferTripOwnership: %w", err)
+	}
+
+	if err := qtx.UpdateParticipantRole(ctx, UpdateParticipantRoleParams{
+		Role: RoleCoOrganizer,
+		ID:   previous.ID,
+	}); err != nil {
+		return fmt.Errorf("pgstore: failed to update previous owner role for TransferTripOwnership: %w", err)
+	}
+
+	if previous.Status != ParticipantStatusConfirmed {
+		name := pgtype.Text{Valid: true, String: trip.OwnerName}
+		if seats > 0 {
+			err = qtx.ConfirmParticipant(ctx, ConfirmParticipantParams{Name: name, ID: previous.ID})
+		} else {
+			err = qtx.WaitlistParticipant(ctx, WaitlistParticipantParams{Name: name, ID: previous.ID})
+		}
+		if err != nil {
+			return fmt.Errorf("pgstore: failed to seat previous owner for TransferTripOwnership: %w", err)
+		}
+	}
+
+	// A previous owner who already had a seat leaves the one of the new owner
+	// free.
+	if err := qtx.fillSeats(ctx, trip.ID); err != nil {
+		return fmt.Errorf("pgstore: failed to promote waitlist for TransferTripOwnership: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgstore: failed to commit tx for TransferTripOwnership: %w", err)
+	}
+
+	return nil
+}
+
+// RescheduleTrip updates the trip and moves all of its activities by shift in
+// a single transaction. If any activity ends up outside the new trip dates
+// nothing is changed, and those activities are returned along with
+// ErrActivitiesOutsideTrip.
+func (q *Queries) RescheduleTrip(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	params UpdateTripParams,
+	shift time.Duration,
+) ([]Activity, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to begin trx for RescheduleTrip: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.UpdateTrip(ctx, params); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to update trip for RescheduleTrip: %w", err)
+	}
+
+	// The trip may have gained seats.
+	if err := qtx.fillSeats(ctx, params.ID); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to promote waitlist for RescheduleTrip: %w", err)
+	}
+
+	if shift != 0 {
+		if err := qtx.ShiftTripActivities(ctx, ShiftTripActivitiesParams{
+			Shift:  pgtype.Interval{Valid: true, Microseconds: shift.Microseconds()},
+			TripID: params.ID,
+		}); err != nil {
+			return nil, fmt.Errorf("pgstore: failed to shift activities for RescheduleTrip: %w", err)
+		}
+	}
+
+	outside, err := qtx.GetTripActivitiesOutsideRange(ctx, GetTripActivitiesOutsideRangeParams{
+		TripID:   params.ID,
+		StartsAt: params.StartsAt,
+		EndsAt:   params.EndsAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to check activities for RescheduleTrip: %w", err)
+	}
+
+	if len(outside) > 0 {
+		return outside, ErrActivitiesOutsideTrip
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to commit tx for RescheduleTrip: %w", err)
+	}
+
+	return nil, nil
+}
+
+// ScheduleActivity creates an activity and returns the activities of the same
+// trip overlapping it. In strict mode nothing is created when there are any,
+// and ErrActivityOverlaps is returned along with them.
+func (q *Queries) ScheduleActivity(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	params CreateActivityParams,
+	strict bool,
+) (uuid.UUID, []Activity, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("pgstore: failed to begin trx for ScheduleActivity: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	// Activity writes on a trip are serialized, otherwise two strict writes
+	// could miss each other and both be committed.
+	if err := qtx.LockTrip(ctx, params.TripID); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("pgstore: failed to lock trip for ScheduleActivity: %w", err)
+	}
+
+	id, err := qtx.CreateActivity(ctx, params)
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("pgstore: failed to insert activity for ScheduleActivity: %w", err)
+	}
+
+	overlaps, err := qtx.GetActivityOverlaps(ctx, id)
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("pgstore: failed to check overlaps for ScheduleActivity: %w", err)
+	}
+
+	if strict && len(overlaps) > 0 {
+		return uuid.Nil, overlaps, ErrActivityOverlaps
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("pgstore: failed to commit tx for ScheduleActivity: %w", err)
+	}
+
+	return id, overlaps, nil
+}
+
+// RescheduleActivity updates an activity of the trip tripID and returns the
+// activities of the trip overlapping it. In strict mode the update is rolled
+// back when there are any, and ErrActivityOverlaps is returned along with
+// them.
+func (q *Queries) RescheduleActivity(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	tripID uuid.UUID,
+	params UpdateActivityParams,
+	strict bool,
+) ([]Activity, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to begin trx for RescheduleActivity: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.LockTrip(ctx, tripID); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to lock trip for RescheduleActivity: %w", err)
+	}
+
+	if err := qtx.UpdateActivity(ctx, params); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to update activity for RescheduleActivity: %w", err)
+	}
+
+	overlaps, err := qtx.GetActivityOverlaps(ctx, params.ID)
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to check overlaps for RescheduleActivity: %w", err)
+	}
+
+	if strict && len(overlaps) > 0 {
+		return overlaps, ErrActivityOverlaps
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to commit tx for RescheduleActivity: %w", err)
+	}
+
+	return overlaps, nil
+}
+
+// ScheduleActivitySeries creates a recurring activity: the series holding its
+// rule and one activity per occurrence, linked to it. It returns the series
+// ID, the ID of each occurrence and the activities of the trip overlapping any
+// of them. In strict mode nothing is created when there are any, and
+// ErrActivityOverlaps is returned along with them.
+func (q *Queries) ScheduleActivitySeries(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	series InsertActivitySeriesParams,
+	occurrences []CreateActivityParams,
+	strict bool,
+) (uuid.UUID, []uuid.UUID, []Activity, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("pgstore: failed to begin trx for ScheduleActivitySeries: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.LockTrip(ctx, series.TripID); err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("pgstore: failed to lock trip for ScheduleActivitySeries: %w", err)
+	}
+
+	seriesID, err := qtx.InsertActivitySeries(ctx, series)
+	if err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("pgstore: failed to insert series for ScheduleActivitySeries: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		occurrence.SeriesID = pgtype.UUID{Valid: true, Bytes: seriesID}
+		id, err := qtx.CreateActivity(ctx, occurrence)
+		if err != nil {
+			return uuid.Nil, nil, nil, fmt.Errorf("pgstore: failed to insert occurrence for ScheduleActivitySeries: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	overlaps, err := qtx.GetActivitySeriesOverlaps(ctx, seriesID)
+	if err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("pgstore: failed to check overlaps for ScheduleActivitySeries: %w", err)
+	}
+
+	if strict && len(overlaps) > 0 {
+		return uuid.Nil, nil, overlaps, ErrActivityOverlaps
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("pgstore: failed to commit tx for ScheduleActivitySeries: %w", err)
+	}
+
+	return seriesID, ids, overlaps, nil
+}
+
+// RescheduleActivitySeries updates every occurrence of a series of the trip,
+// each one moved by params.Shift, and returns the activities of the trip
+// overlapping any of them. When an occurrence would fall outside the trip,
+// nothing is updated and ErrActivitiesOutsideTrip is returned along with the
+// activities outside of it. In strict mode the same goes for overlaps, with
+// ErrActivityOverlaps.
+func (q *Queries) RescheduleActivitySeries(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	trip Trip,
+	params UpdateActivitySeriesParams,
+	strict bool,
+) ([]Activity, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to begin trx for RescheduleActivitySeries: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.LockTrip(ctx, trip.ID); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to lock trip for RescheduleActivitySeries: %w", err)
+	}
+
+	if err := qtx.UpdateActivitySeries(ctx, params); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to update series for RescheduleActivitySeries: %w", err)
+	}
+
+	outside, err := qtx.GetTripActivitiesOutsideRange(ctx, GetTripActivitiesOutsideRangeParams{
+		TripID:   trip.ID,
+		StartsAt: trip.StartsAt,
+		EndsAt:   trip.EndsAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to check activities for RescheduleActivitySeries: %w", err)
+	}
+
+	if len(outside) > 0 {
+		return outside, ErrActivitiesOutsideTrip
+	}
+
+	overlaps, err := qtx.GetActivitySeriesOverlaps(ctx, params.SeriesID)
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to check overlaps for RescheduleActivitySeries: %w", err)
+	}
+
+	if strict && len(overlaps) > 0 {
+		return overlaps, ErrActivityOverlaps
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to commit tx for RescheduleActivitySeries: %w", err)
+	}
+
+	return overlaps, nil
+}
+
+// ImportActivityParams is an activity imported from a calendar event. Key
+// identifies the event, usually by its UID, and is recorded so that the event
+// isn't imported twice.
+type ImportActivityParams struct {
+	Key      string
+	Activity CreateActivityParams
+}
+
+// ImportActivities creates the activities of the trip tripID in a single
+// transaction, returning the ID of each of them. Either all of them are
+// created or none is.
+func (q *Queries) ImportActivities(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	tripID uuid.UUID,
+	activities []ImportActivityParams,
+) ([]uuid.UUID, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to begin trx for ImportActivities: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.LockTrip(ctx, tripID); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to lock trip for ImportActivities: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(activities))
+	for _, activity := range activities {
+		id, err := qtx.CreateActivity(ctx, activity.Activity)
+		if err != nil {
+			return nil, fmt.Errorf("pgstore: failed to insert activity for ImportActivities: %w", err)
+		}
+
+		if err := qtx.InsertActivityImport(ctx, InsertActivityImportParams{
+			ActivityID: id,
+			TripID:     tripID,
+			Uid:        activity.Key,
+		}); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+				return nil, ErrActivityImported
+			}
+			return nil, fmt.Errorf("pgstore: failed to insert activity import for ImportActivities: %w", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to commit tx for ImportActivities: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (q *Queries) useToken(ctx context.Context, token UseTokenParams) error {
+	used, err := q.UseToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if used == 0 {
+		return ErrTokenUsed
+	}
+
+	return nil
+}
+
+// enqueueInvite records the invitation on the participant row and enqueues
+// its e-mail.
+func (q *Queries) enqueueInvite(ctx context.Context, tripID, participantID uuid.UUID, cooldown time.Duration) error {
+	marked, err := q.MarkParticipantInvited(ctx, MarkParticipantInvitedParams{
+		ID:       participantID,
+		Cooldown: pgtype.Interval{Valid: true, Microseconds: cooldown.Microseconds()},
+	})
+	if err != nil {
+		return err
+	}
+
+	if marked == 0 {
+		return ErrInviteCooldown
+	}
+
+	return q.EnqueueEmail(ctx, EnqueueEmailParams{
+		Kind:          EmailKindInviteParticipant,
+		TripID:        pgtype.UUID{Valid: true, Bytes: tripID},
+		ParticipantID: pgtype.UUID{Valid: true, Bytes: participantID},
+	})
+}
+
+// seatsLeft locks the trip row, so that concurrent confirmations are counted
+// one after the other, and returns how many participants can still be
+// confirmed. Trips without max_participants always have seats left.
+func (q *Queries) seatsLeft(ctx context.Context, tripID uuid.UUID) (int32, error) {
+	maxParticipants, err := q.GetTripCapacityForUpdate(ctx, tripID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !maxParticipants.Valid {
+		return math.MaxInt32, nil
+	}
+
+	confirmed, err := q.CountConfirmedParticipants(ctx, tripID)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(maxParticipants.Int32-int32(confirmed), 0), nil
+}
+
+// fillSeats confirms waitlisted participants, in order of arrival, while the
+// trip has seats left, and enqueues an e-mail telling each of them.
+func (q *Queries) fillSeats(ctx context.Context, tripID uuid.UUID) error {
+	seats, err := q.seatsLeft(ctx, tripID)
+	if err != nil {
+		return err
+	}
+
+	if seats == 0 {
+		return nil
+	}
+
+	promoted, err := q.PromoteWaitlistedParticipants(ctx, PromoteWaitlistedParticipantsParams{
+		TripID: tripID,
+		Limit:  seats,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, participantID := range promoted {
+		if err := q.EnqueueEmail(ctx, EnqueueEmailParams{
+			Kind:          EmailKindWaitlistPromoted,
+			TripID:        pgtype.UUID{Valid: true, Bytes: tripID},
+			ParticipantID: pgtype.UUID{Valid: true, Bytes: participantID},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyParticipants inserts the new e-mails among invites with a single COPY
+// and, when the trip is confirmed, enqueues their invitations. Addresses
+// already on the trip and the owner address are only reported in the
+// results, which follow the order of invites.
+func (q *Queries) copyParticipants(
+	ctx context.Context,
+	trip Trip,
+	invites []InviteParticipantsToTripParams,
+) ([]InviteResult, error) {
+	existing, err := q.GetParticipants(ctx, GetParticipantsParams{TripID: trip.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	participantIDs := make(map[string]uuid.UUID, len(existing))
+	for _, participant := range existing {
+		participantIDs[NormalizeEmail(participant.Email)] = participant.ID
+	}
+
+	owner := NormalizeEmail(trip.OwnerEmail)
+	results := make([]InviteResult, len(invites))
+	rows := make([]InviteParticipantsToTripParams, 0, len(invites))
+	for i, invite := range invites {
+		email := NormalizeEmail(invite.Email)
+		results[i] = InviteResult{Email: email}
+
+		if email == owner {
+			results[i].Outcome = InviteOutcomeOwner
+			continue
+		}
+
+		// Repeated e-mails are already invited by their first occurrence,
+		// whose ID is only known after the COPY.
+		if id, ok := participantIDs[email]; ok {
+			results[i].Outcome = InviteOutcomeAlreadyInvited
+			results[i].ParticipantID = id
+			continue
+		}
+		participantIDs[email] = uuid.Nil
+
+		results[i].Outcome = InviteOutcomeCreated
+		rows = append(rows, InviteParticipantsToTripParams{
+			TripID: trip.ID,
+			Email:  email,
+			Name:   invite.Name,
+		})
+	}
+
+	if len(rows) == 0 {
+		return results, nil
+	}
+
+	if _, err := q.InviteParticipantsToTrip(ctx, rows); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, ErrInviteConflict
+		}
+		return nil, err
+	}
+
+	created, err := q.GetParticipants(ctx, GetParticipantsParams{
+		TripID: trip.ID,
+		Status: pgtype.Text{Valid: true, String: ParticipantStatusInvited},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, participant := range created {
+		participantIDs[NormalizeEmail(participant.Email)] = participant.ID
+	}
+
+	for i := range results {
+		if results[i].Outcome != InviteOutcomeOwner && results[i].ParticipantID == uuid.Nil {
+			results[i].ParticipantID = participantIDs[results[i].Email]
+		}
+
+		if results[i].Outcome == InviteOutcomeCreated && trip.IsConfirmed {
+			if err := q.enqueueInvite(ctx, trip.ID, results[i].ParticipantID, 0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,158 @@
+package pgstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// newTestPool connects to the database of JOURNEY_TEST_DATABASE_URL and
+// migrates a schema of its own, dropped at the end of the test. Tests are
+// skipped when the variable isn't set.
+func newTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	url := os.Getenv("JOURNEY_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("JOURNEY_TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	admin, err := pgxpool.New(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(admin.Close)
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _, _ = admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE") })
+
+	config, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.ConnConfig.RuntimeParams["search_path"] = schema + ",public"
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	migrations, err := filepath.Glob("migrations/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(migrations)
+	for _, migration := range migrations {
+		sql, err := os.ReadFile(migration)
+		if err != nil {
+			t.Fatal(err)
+		}
+		up, _, _ := strings.Cut(string(sql), "---- create above / drop below ----")
+		if _, err := pool.Exec(ctx, up); err != nil {
+			t.Fatalf("failed to apply %s: %v", migration, err)
+		}
+	}
+
+	return pool
+}
+
+func TestTransferTripOwnershipToWaitlistedParticipant(t *testing.T) {
+	pool := newTestPool(t)
+	ctx := context.Background()
+	q := New(pool)
+
+	startsAt := time.Now().Add(24 * time.Hour)
+	tripID, err := q.InsertTrip(ctx, InsertTripParams{
+		Destination:     "Lisbon",
+		OwnerEmail:      "owner@example.com",
+		OwnerName:       "Owner",
+		StartsAt:        pgtype.Timestamptz{Valid: true, Time: startsAt},
+		EndsAt:          pgtype.Timestamptz{Valid: true, Time: startsAt.Add(72 * time.Hour)},
+		Timezone:        "Europe/Lisbon",
+		Locale:          "en-US",
+		MaxParticipants: pgtype.Int4{Valid: true, Int32: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	insert := func(email, status string) Participant {
+		t.Helper()
+
+		id, err := q.InsertParticipant(ctx, InsertParticipantParams{TripID: tripID, Email: email})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := q.UpdateParticipantStatus(ctx, UpdateParticipantStatusParams{Status: status, ID: id}); err != nil {
+			t.Fatal(err)
+		}
+		participant, err := q.GetParticipant(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return participant
+	}
+
+	// The only seat is taken, and the previous owner already has a declined
+	// row for their e-mail.
+	insert("seated@example.com", ParticipantStatusConfirmed)
+	waitlisted := insert("waitlisted@example.com", ParticipantStatusWaitlisted)
+	insert("OWNER@example.com", ParticipantStatusDeclined)
+
+	trip, err := q.GetTrip(ctx, tripID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := q.TransferTripOwnership(ctx, pool, trip, waitlisted); err != nil {
+		t.Fatal(err)
+	}
+
+	trip, err = q.GetTrip(ctx, tripID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trip.OwnerEmail != "waitlisted@example.com" {
+		t.Errorf("owner is %s, want waitlisted@example.com", trip.OwnerEmail)
+	}
+
+	confirmed, err := q.CountConfirmedParticipants(ctx, tripID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if confirmed != 1 {
+		t.Errorf("%d participants are confirmed, want the 1 seat of the trip", confirmed)
+	}
+
+	previous, err := q.GetTripParticipantByEmail(ctx, GetTripParticipantByEmailParams{
+		TripID: tripID,
+		Email:  "owner@example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous.Status != ParticipantStatusWaitlisted || previous.Role != RoleCoOrganizer || previous.Name.String != "Owner" {
+		t.Errorf("previous owner is a %s %s named %q, want a waitlisted co-organizer named Owner",
+			previous.Status, previous.Role, previous.Name.String)
+	}
+
+	participants, err := q.GetParticipants(ctx, GetParticipantsParams{TripID: tripID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(participants) != 2 {
+		t.Errorf("trip has %d participants, want 2", len(participants))
+	}
+}
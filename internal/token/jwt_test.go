@@ -0,0 +1,86 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signJWT returns a JWT with the given header and claims, signed with
+// HMAC-SHA256 whatever its alg says.
+func signJWT(header, claims string, secret []byte) string {
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWTVerifier(t *testing.T) {
+	secret := []byte(strings.Repeat("j", MinSecretLength))
+	v, err := NewJWTVerifier(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().Unix()
+	hs256 := `{"alg":"HS256","typ":"JWT"}`
+	valid := `{"email":"ana@example.com","exp":` + itoa(now+3600) + `}`
+
+	validToken := signJWT(hs256, valid, secret)
+	encoded := func(segment string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(segment))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr error
+	}{
+		{name: "valid", token: validToken, want: "ana@example.com"},
+		{
+			name:  "valid with nbf in the past",
+			token: signJWT(hs256, `{"email":"ana@example.com","exp":`+itoa(now+3600)+`,"nbf":`+itoa(now-60)+`}`, secret),
+			want:  "ana@example.com",
+		},
+		{name: "alg none", token: encoded(`{"alg":"none"}`) + "." + encoded(valid) + ".", wantErr: ErrInvalid},
+		{name: "alg HS512", token: signJWT(`{"alg":"HS512"}`, valid, secret), wantErr: ErrInvalid},
+		{name: "alg RS256", token: signJWT(`{"alg":"RS256"}`, valid, secret), wantErr: ErrInvalid},
+		{name: "no alg", token: signJWT(`{"typ":"JWT"}`, valid, secret), wantErr: ErrInvalid},
+		{name: "tampered signature", token: validToken[:len(validToken)-2] + "xx", wantErr: ErrInvalid},
+		{name: "signed with another secret", token: signJWT(hs256, valid, []byte(strings.Repeat("o", MinSecretLength))), wantErr: ErrInvalid},
+		{name: "expired", token: signJWT(hs256, `{"email":"ana@example.com","exp":`+itoa(now-1)+`}`, secret), wantErr: ErrExpired},
+		{
+			name:    "not valid yet",
+			token:   signJWT(hs256, `{"email":"ana@example.com","exp":`+itoa(now+3600)+`,"nbf":`+itoa(now+600)+`}`, secret),
+			wantErr: ErrInvalid,
+		},
+		{name: "no email", token: signJWT(hs256, `{"exp":`+itoa(now+3600)+`}`, secret), wantErr: ErrInvalid},
+		{name: "no exp", token: signJWT(hs256, `{"email":"ana@example.com"}`, secret), wantErr: ErrInvalid},
+		{name: "two segments", token: encoded(hs256) + "." + encoded(valid), wantErr: ErrInvalid},
+		{name: "header not JSON", token: signJWT("HS256", valid, secret), wantErr: ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Verify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
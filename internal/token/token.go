@@ -0,0 +1,115 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Purposes a token can be issued for. A token is only accepted by the
+// endpoint of its purpose.
+const (
+	PurposeConfirmTrip        = "confirm_trip"
+	PurposeConfirmParticipant = "confirm_participant"
+	PurposeLogin              = "login"
+	PurposeSession            = "session"
+)
+
+// MinSecretLength is the minimum length, in bytes, of the signing secret.
+const MinSecretLength = 32
+
+var (
+	ErrInvalid = errors.New("token: invalid token")
+	ErrExpired = errors.New("token: expired token")
+)
+
+// Claims are the values carried by a token. ID identifies the token itself,
+// so it can be marked as used. Subject is the trip or participant a
+// confirmation token refers to, and Email the address a login or session
+// token was issued to.
+type Claims struct {
+	ID        uuid.UUID `json:"jti"`
+	Purpose   string    `json:"pur"`
+	Subject   uuid.UUID `json:"sub"`
+	Email     string    `json:"email,omitempty"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// Signer issues and verifies HMAC-SHA256 signed tokens, encoded as
+// base64url(claims) + "." + base64url(signature).
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret []byte) (Signer, error) {
+	if len(secret) < MinSecretLength {
+		return Signer{}, fmt.Errorf("token: secret must have at least %d bytes", MinSecretLength)
+	}
+	return Signer{secret}, nil
+}
+
+// Issue returns a token for subject that expires after ttl.
+func (s Signer) Issue(purpose string, subject uuid.UUID, ttl time.Duration) (string, error) {
+	return s.issue(Claims{Purpose: purpose, Subject: subject}, ttl)
+}
+
+// IssueForEmail returns a token for the owner of email that expires after ttl.
+func (s Signer) IssueForEmail(purpose, email string, ttl time.Duration) (string, error) {
+	return s.issue(Claims{Purpose: purpose, Email: email}, ttl)
+}
+
+func (s Signer) issue(claims Claims, ttl time.Duration) (string, error) {
+	claims.ID = uuid.New()
+	claims.ExpiresAt = time.Now().Add(ttl).UTC().Truncate(time.Second)
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("token: failed to encode claims: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), nil
+}
+
+// Verify checks the signature and expiration of token and returns its claims.
+// Whether the token was already used is up to the caller.
+func (s Signer) Verify(token string) (Claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Claims{}, ErrInvalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, s.sign(encoded)) {
+		return Claims{}, ErrInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Claims{}, ErrInvalid
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, ErrInvalid
+	}
+
+	if !time.Now().Before(claims.ExpiresAt) {
+		return Claims{}, ErrExpired
+	}
+
+	return claims, nil
+}
+
+func (s Signer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
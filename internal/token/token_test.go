@@ -0,0 +1,121 @@
+package token
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTestSigner(t *testing.T, secret string) Signer {
+	t.Helper()
+
+	s, err := NewSigner([]byte(strings.Repeat(secret, MinSecretLength)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestNewSignerRejectsShortSecrets(t *testing.T) {
+	if _, err := NewSigner([]byte("short")); err == nil {
+		t.Error("NewSigner() accepted a short secret")
+	}
+}
+
+func TestSignerIssueAndVerify(t *testing.T) {
+	s := newTestSigner(t, "s")
+	subject := uuid.New()
+
+	raw, err := s.Issue(PurposeConfirmParticipant, subject, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := s.Verify(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Purpose != PurposeConfirmParticipant || claims.Subject != subject || claims.ID == uuid.Nil {
+		t.Errorf("Verify() = %+v", claims)
+	}
+	if wait := time.Until(claims.ExpiresAt); wait <= 0 || wait > time.Hour {
+		t.Errorf("token expires in %v, want an hour", wait)
+	}
+
+	raw, err = s.IssueForEmail(PurposeLogin, "ana@example.com", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err = s.Verify(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Purpose != PurposeLogin || claims.Email != "ana@example.com" {
+		t.Errorf("Verify() = %+v", claims)
+	}
+}
+
+func TestSignerVerifyRejects(t *testing.T) {
+	s := newTestSigner(t, "s")
+
+	valid, err := s.Issue(PurposeConfirmTrip, uuid.New(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, signature, _ := strings.Cut(valid, ".")
+
+	// The claims of valid with another purpose, signed by the original
+	// signature.
+	var claims Claims
+	data, _ := base64.RawURLEncoding.DecodeString(payload)
+	if err := json.Unmarshal(data, &claims); err != nil {
+		t.Fatal(err)
+	}
+	claims.Purpose = PurposeLogin
+	data, _ = json.Marshal(claims)
+	repurposed := base64.RawURLEncoding.EncodeToString(data)
+
+	// A signature with its last character changed.
+	last := signature[len(signature)-1:]
+	tampered := "A"
+	if last == "A" {
+		tampered = "B"
+	}
+
+	expired, err := s.Issue(PurposeConfirmTrip, uuid.New(), -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := newTestSigner(t, "o").Issue(PurposeConfirmTrip, uuid.New(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{name: "tampered signature", token: payload + "." + signature[:len(signature)-1] + tampered, want: ErrInvalid},
+		{name: "tampered claims", token: repurposed + "." + signature, want: ErrInvalid},
+		{name: "signed with another secret", token: other, want: ErrInvalid},
+		{name: "expired", token: expired, want: ErrExpired},
+		{name: "no signature", token: payload, want: ErrInvalid},
+		{name: "signature not in base64", token: payload + ".!!!", want: ErrInvalid},
+		{name: "empty", token: "", want: ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Verify(tt.token); !errors.Is(err, tt.want) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}